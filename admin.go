@@ -0,0 +1,231 @@
+package main
+
+import (
+	"canvas/catalogue"
+	"canvas/connections"
+	"canvas/functions"
+	"canvas/models"
+	"encoding/json"
+	"log"
+	"net/http"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// #region Helpers
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	err := json.NewEncoder(w).Encode(body)
+	if err != nil {
+		log.Println("Error writing json response: ", err)
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{"error": message})
+}
+
+// #endregion Helpers
+
+// #region Sanctions
+
+func getSanctions(w http.ResponseWriter, r *http.Request) {
+	_, isModerator := functions.VerifyModerator(r.Header.Get("X-Auth-Token"))
+	if !isModerator {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	userId := r.URL.Query().Get("userId")
+	if _, err := primitive.ObjectIDFromHex(userId); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid User ID")
+		return
+	}
+	sanctions, err := functions.GetSanctions(userId, connections.RedisClient)
+	if err != nil {
+		log.Println("ERR26: ", err)
+		writeError(w, http.StatusInternalServerError, "Error getting sanctions!")
+		return
+	}
+	writeJSON(w, http.StatusOK, sanctions)
+}
+
+func setSanction(w http.ResponseWriter, r *http.Request) {
+	moderatorId, isModerator := functions.VerifyModerator(r.Header.Get("X-Auth-Token"))
+	if !isModerator {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	var request models.SanctionRequest
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if _, err := primitive.ObjectIDFromHex(request.UserId); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid User ID")
+		return
+	}
+	if request.DurationSecs < 0 {
+		writeError(w, http.StatusBadRequest, "Invalid duration")
+		return
+	}
+	sanction := models.Sanction{
+		UserId:   request.UserId,
+		Type:     request.Type,
+		Reason:   request.Reason,
+		IssuedBy: moderatorId,
+		IssuedAt: time.Now().Unix(),
+	}
+	if request.DurationSecs > 0 {
+		sanction.ExpiresAt = time.Now().Add(time.Duration(request.DurationSecs) * time.Second).Unix()
+	}
+	err = functions.SetSanction(sanction, connections.RedisClient)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	log.Printf("Moderator %v issued %v to user %v\n", moderatorId, sanction.Type, sanction.UserId)
+	writeJSON(w, http.StatusOK, sanction)
+}
+
+func removeSanction(w http.ResponseWriter, r *http.Request) {
+	moderatorId, isModerator := functions.VerifyModerator(r.Header.Get("X-Auth-Token"))
+	if !isModerator {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	userId := r.URL.Query().Get("userId")
+	sanctionType := r.URL.Query().Get("type")
+	if _, err := primitive.ObjectIDFromHex(userId); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid User ID")
+		return
+	}
+	err := functions.RemoveSanction(userId, sanctionType, connections.RedisClient)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	log.Printf("Moderator %v lifted %v from user %v\n", moderatorId, sanctionType, userId)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// #endregion Sanctions
+
+// #region Lifecycle
+
+func getLifecycle(w http.ResponseWriter, r *http.Request) {
+	_, isModerator := functions.VerifyModerator(r.Header.Get("X-Auth-Token"))
+	if !isModerator {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	canvasIdentifier := r.PathValue("canvasIdentifier")
+	if !functions.CanvasExists(catalogue.CANVAS_LIST, canvasIdentifier) {
+		writeError(w, http.StatusNotFound, "Invalid Canvas Identifier")
+		return
+	}
+	schedule, err := functions.GetSchedule(canvasIdentifier, connections.RedisClient)
+	if err != nil {
+		log.Println("ERR48: ", err)
+		writeError(w, http.StatusInternalServerError, "Error getting lifecycle!")
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"current":  functions.CanvasLifecycle(canvasIdentifier),
+		"schedule": schedule,
+	})
+}
+
+// setLifecycle replaces the schedule of a canvas, clients are notified once the watcher picks up the change
+func setLifecycle(w http.ResponseWriter, r *http.Request) {
+	moderatorId, isModerator := functions.VerifyModerator(r.Header.Get("X-Auth-Token"))
+	if !isModerator {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	canvasIdentifier := r.PathValue("canvasIdentifier")
+	if !functions.CanvasExists(catalogue.CANVAS_LIST, canvasIdentifier) {
+		writeError(w, http.StatusNotFound, "Invalid Canvas Identifier")
+		return
+	}
+	var schedule []models.LifecyclePhase
+	err := json.NewDecoder(r.Body).Decode(&schedule)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	err = functions.SetSchedule(canvasIdentifier, schedule, connections.RedisClient)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	log.Printf("Moderator %v changed the lifecycle of %v\n", moderatorId, canvasIdentifier)
+	writeJSON(w, http.StatusOK, schedule)
+}
+
+// #endregion Lifecycle
+
+// #region Resize
+
+func resizeCanvas(w http.ResponseWriter, r *http.Request) {
+	moderatorId, isModerator := functions.VerifyModerator(r.Header.Get("X-Auth-Token"))
+	if !isModerator {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	canvasIdentifier := r.PathValue("canvasIdentifier")
+	if !functions.CanvasExists(catalogue.CANVAS_LIST, canvasIdentifier) {
+		writeError(w, http.StatusNotFound, "Invalid Canvas Identifier")
+		return
+	}
+	var request models.ResizeRequest
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	err = functions.ResizeCanvas(canvasIdentifier, request.Width, request.Height, connections.RedisClient, connections.MongoClient)
+	if err != nil {
+		log.Println("ERR52: ", err)
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	log.Printf("Moderator %v resized %v to %vx%v\n", moderatorId, canvasIdentifier, request.Width, request.Height)
+	writeJSON(w, http.StatusOK, request)
+}
+
+// #endregion Resize
+
+// #region Teams
+
+// setTeam assigns a user to a team, overriding the team claim of their token. An empty team removes the assignment.
+func setTeam(w http.ResponseWriter, r *http.Request) {
+	moderatorId, isModerator := functions.VerifyModerator(r.Header.Get("X-Auth-Token"))
+	if !isModerator {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	var request models.TeamRequest
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if _, err := primitive.ObjectIDFromHex(request.UserId); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid User ID")
+		return
+	}
+	err = functions.SetTeam(request.UserId, request.Team, connections.RedisClient)
+	if err != nil {
+		log.Println("ERR57: ", err)
+		writeError(w, http.StatusInternalServerError, "Error setting team!")
+		return
+	}
+	log.Printf("Moderator %v moved user %v to team %q\n", moderatorId, request.UserId, request.Team)
+	writeJSON(w, http.StatusOK, request)
+}
+
+// #endregion Teams
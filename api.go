@@ -0,0 +1,226 @@
+package main
+
+import (
+	"canvas/catalogue"
+	"canvas/connections"
+	"canvas/functions"
+	"canvas/models"
+	canvas "canvas/proto"
+	"encoding/json"
+	"fmt"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// #region Canvases
+
+func listCanvases(w http.ResponseWriter, r *http.Request) {
+	canvases := []models.CanvasInfo{}
+	for _, canvasIdentifier := range catalogue.CANVAS_LIST {
+		canvases = append(canvases, functions.GetCanvasInfo(canvasIdentifier))
+	}
+	writeJSON(w, http.StatusOK, canvases)
+}
+
+func getCanvasInfo(w http.ResponseWriter, r *http.Request) {
+	canvasIdentifier := r.PathValue("canvasIdentifier")
+	if !functions.CanvasExists(catalogue.CANVAS_LIST, canvasIdentifier) {
+		writeError(w, http.StatusNotFound, "Invalid Canvas Identifier")
+		return
+	}
+	writeJSON(w, http.StatusOK, functions.GetCanvasInfo(canvasIdentifier))
+}
+
+// getSnapshot returns every pixel of the canvas row by row
+func getSnapshot(w http.ResponseWriter, r *http.Request) {
+	canvasIdentifier := r.PathValue("canvasIdentifier")
+	if !functions.CanvasExists(catalogue.CANVAS_LIST, canvasIdentifier) {
+		writeError(w, http.StatusNotFound, "Invalid Canvas Identifier")
+		return
+	}
+	if rateLimited(w, functions.MessageBuckets(functions.ClientIP(r), "", models.GET_CANVAS)) {
+		return
+	}
+	pixels, err := functions.GetCanvas(canvasIdentifier, connections.RedisClient)
+	if err != nil && err != redis.Nil {
+		log.Println("ERR73: ", err)
+		writeError(w, http.StatusInternalServerError, "Error getting canvas!")
+		return
+	}
+	width, height := functions.CanvasSize(canvasIdentifier)
+	writeJSON(w, http.StatusOK, models.CanvasRegion{Width: width, Height: height, Pixels: pixels})
+}
+
+// getRegion returns a rectangle of the canvas, e.g. ?x=10&y=20&width=50&height=50
+func getRegion(w http.ResponseWriter, r *http.Request) {
+	canvasIdentifier := r.PathValue("canvasIdentifier")
+	if !functions.CanvasExists(catalogue.CANVAS_LIST, canvasIdentifier) {
+		writeError(w, http.StatusNotFound, "Invalid Canvas Identifier")
+		return
+	}
+	if rateLimited(w, functions.MessageBuckets(functions.ClientIP(r), "", models.GET_CANVAS)) {
+		return
+	}
+	var bounds [4]int32
+	for i, name := range []string{"x", "y", "width", "height"} {
+		value, err := strconv.ParseInt(r.URL.Query().Get(name), 10, 32)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("Invalid %v", name))
+			return
+		}
+		bounds[i] = int32(value)
+	}
+	region, err := functions.GetRegion(canvasIdentifier, bounds[0], bounds[1], bounds[2], bounds[3], connections.RedisClient)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, region)
+}
+
+// #endregion Canvases
+
+// #region Pixels
+
+// getPixelOwner returns who placed a pixel last and when
+func getPixelOwner(w http.ResponseWriter, r *http.Request) {
+	canvasIdentifier := r.PathValue("canvasIdentifier")
+	if !functions.CanvasExists(catalogue.CANVAS_LIST, canvasIdentifier) {
+		writeError(w, http.StatusNotFound, "Invalid Canvas Identifier")
+		return
+	}
+	if rateLimited(w, functions.MessageBuckets(functions.ClientIP(r), "", models.VIEW_PIXEL)) {
+		return
+	}
+	pixelId, err := strconv.ParseInt(r.PathValue("pixelId"), 10, 32)
+	width, height := functions.CanvasSize(canvasIdentifier)
+	if err != nil || pixelId < 0 || pixelId >= int64(width*height) {
+		writeError(w, http.StatusBadRequest, "Invalid Pixel ID")
+		return
+	}
+	pixelData, _, err := functions.GetPixelData(int32(pixelId), canvasIdentifier, connections.MongoClient)
+	if err != nil {
+		log.Println("ERR74: ", err)
+		writeError(w, http.StatusInternalServerError, "Error getting pixel!")
+		return
+	}
+	writeJSON(w, http.StatusOK, pixelData)
+}
+
+// placePixels lets trusted integrations place pixels with the same checks as the websocket
+func placePixels(w http.ResponseWriter, r *http.Request) {
+	xAuthToken := r.Header.Get("X-Auth-Token")
+	integrationId, isIntegration := functions.VerifyIntegration(xAuthToken)
+	if !isIntegration {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	canvasIdentifier := r.PathValue("canvasIdentifier")
+	if !functions.CanvasExists(catalogue.CANVAS_LIST, canvasIdentifier) {
+		writeError(w, http.StatusNotFound, "Invalid Canvas Identifier")
+		return
+	}
+	var placement models.PlacementRequest
+	err := json.NewDecoder(http.MaxBytesReader(w, r.Body, int64(models.MAX_MESSAGE_SIZE))).Decode(&placement)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid placement")
+		return
+	}
+
+	request := &canvas.RequestMessage{
+		MessageType: models.SET_CANVAS,
+		PixelId:     placement.PixelId,
+		Color:       placement.Color,
+		RequestId:   placement.RequestId,
+	}
+	if len(placement.PixelIds) > 0 || placement.Brush != models.BRUSH_LIST {
+		request.MessageType = models.SET_PIXELS
+		request.PixelIds = placement.PixelIds
+		request.Brush = placement.Brush
+		request.ToPixelId = placement.ToPixelId
+		request.BrushSize = placement.BrushSize
+	}
+
+	allowed, retryAfter, err := functions.TakeTokens(functions.MessageBuckets(functions.ClientIP(r), integrationId, request.MessageType), 1, connections.RedisClient)
+	if err != nil {
+		log.Println("ERR86: ", err)
+	} else if !allowed {
+		response := models.PlacementResponse{
+			Message:      "Too many requests!",
+			Reason:       canvas.Reason_THROTTLED.String(),
+			RetryAfterMs: retryAfter.Milliseconds(),
+		}
+		w.Header().Set("Retry-After", fmt.Sprint(int(math.Ceil(retryAfter.Seconds()))))
+		writeJSON(w, http.StatusTooManyRequests, response)
+		return
+	}
+
+	placer := models.Placer{
+		UserId:           integrationId,
+		TeamClaim:        functions.TeamFromToken(xAuthToken),
+		CanvasIdentifier: canvasIdentifier,
+		Origin:           models.EventOrigin{RequestId: placement.RequestId},
+	}
+	result := functions.PlacePixels(request, placer, connections.RedisClient, connections.MongoClient)
+
+	response := models.PlacementResponse{
+		Message:         result.Response.GetMessage(),
+		PixelId:         result.Response.GetPixelId(),
+		RetryAfterMs:    result.Response.GetRetryAfterMs(),
+		PixelsAvailable: result.Response.GetPixelsAvailable(),
+		NextPixelAt:     result.Response.GetNextPixelAt(),
+	}
+	if result.Credits != nil {
+		response.PixelsAvailable = int32(result.Credits.Balance)
+		response.NextPixelAt = result.Credits.NextRefillAt
+	}
+	status := http.StatusOK
+	if result.Response.GetMessageType() != models.Success {
+		response.Reason = result.Response.GetReason().String()
+		status = placementStatus(result.Response.GetReason())
+	}
+	if response.RetryAfterMs > 0 {
+		w.Header().Set("Retry-After", fmt.Sprint(int(math.Ceil(float64(response.RetryAfterMs)/1000))))
+	}
+	writeJSON(w, status, response)
+}
+
+// placementStatus maps the reason a placement was refused to its HTTP status
+func placementStatus(reason canvas.Reason) int {
+	switch reason {
+	case canvas.Reason_INVALID_BRUSH, canvas.Reason_INVALID_PIXEL, canvas.Reason_INVALID_MESSAGE:
+		return http.StatusBadRequest
+	case canvas.Reason_BANNED, canvas.Reason_MUTED, canvas.Reason_TEAM_REGION:
+		return http.StatusForbidden
+	case canvas.Reason_CANVAS_NOT_OPEN, canvas.Reason_CANVAS_RESIZING:
+		return http.StatusConflict
+	case canvas.Reason_USER_COOLDOWN, canvas.Reason_PIXEL_COOLDOWN, canvas.Reason_NOT_ENOUGH_CREDITS, canvas.Reason_THROTTLED:
+		return http.StatusTooManyRequests
+	}
+	return http.StatusInternalServerError
+}
+
+// #endregion Pixels
+
+// #region Rate Limit
+
+// rateLimited takes a token from every bucket, answering 429 when one of them is empty
+func rateLimited(w http.ResponseWriter, buckets []functions.Bucket) bool {
+	allowed, retryAfter, err := functions.TakeTokens(buckets, 1, connections.RedisClient)
+	if err != nil {
+		log.Println("ERR88: ", err)
+		return false
+	}
+	if !allowed {
+		w.Header().Set("Retry-After", fmt.Sprint(int(math.Ceil(retryAfter.Seconds()))))
+		writeError(w, http.StatusTooManyRequests, "Too many requests!")
+		return true
+	}
+	return false
+}
+
+// #endregion Rate Limit
@@ -0,0 +1,160 @@
+package catalogue
+
+import (
+	"bufio"
+	"canvas/models"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	_ "image/gif"
+	_ "image/jpeg"
+	"image/png"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// #region Mask Formats
+
+// Mask files come in two formats:
+//
+// RLE (.rle) is text: the width and height, then the lengths of alternating runs of blocked and
+// placeable cells in row-major order, always starting with a blocked run (which may be 0).
+//
+//	200 200
+//	8043 12 188 12 ...
+//
+// PNG (.png) is an image of the mask size where opaque light pixels are placeable, usually 1-bit.
+
+const (
+	MASK_EXT_RLE = ".rle"
+	MASK_EXT_PNG = ".png"
+)
+
+func DecodeMaskRLE(r io.Reader) (*models.Mask, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Split(bufio.ScanWords)
+	next := func() (int32, error) {
+		if !scanner.Scan() {
+			if scanner.Err() != nil {
+				return 0, scanner.Err()
+			}
+			return 0, io.EOF
+		}
+		var value int32
+		_, err := fmt.Sscan(scanner.Text(), &value)
+		if err != nil || value < 0 {
+			return 0, fmt.Errorf("invalid rle value %q", scanner.Text())
+		}
+		return value, nil
+	}
+	width, err := next()
+	if err != nil {
+		return nil, err
+	}
+	height, err := next()
+	if err != nil {
+		return nil, err
+	}
+	if width == 0 || height == 0 || width > models.MAX_CANVAS_SIZE || height > models.MAX_CANVAS_SIZE {
+		return nil, fmt.Errorf("invalid mask size %vx%v", width, height)
+	}
+	mask := models.NewMask(width, height)
+	cell, placeable := 0, false
+	for {
+		run, err := next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if cell+int(run) > len(mask.Cells) {
+			return nil, errors.New("rle runs are longer than the mask")
+		}
+		for i := 0; i < int(run); i++ {
+			mask.Cells[cell] = placeable
+			cell++
+		}
+		placeable = !placeable
+	}
+	if cell != len(mask.Cells) {
+		return nil, fmt.Errorf("rle runs cover %v of %v cells", cell, len(mask.Cells))
+	}
+	return mask, nil
+}
+
+func EncodeMaskRLE(w io.Writer, mask *models.Mask) error {
+	writer := bufio.NewWriter(w)
+	fmt.Fprintf(writer, "%d %d\n", mask.Width, mask.Height)
+	run, placeable, written := 0, false, 0
+	flush := func() {
+		separator := " "
+		if written%20 == 19 {
+			separator = "\n"
+		}
+		fmt.Fprintf(writer, "%d%s", run, separator)
+		written++
+	}
+	for _, cell := range mask.Cells {
+		if cell != placeable {
+			flush()
+			run, placeable = 0, cell
+		}
+		run++
+	}
+	flush()
+	fmt.Fprintln(writer)
+	return writer.Flush()
+}
+
+func DecodeMaskPNG(r io.Reader) (*models.Mask, error) {
+	img, _, err := image.Decode(r)
+	if err != nil {
+		return nil, err
+	}
+	bounds := img.Bounds()
+	if bounds.Dx() > models.MAX_CANVAS_SIZE || bounds.Dy() > models.MAX_CANVAS_SIZE {
+		return nil, fmt.Errorf("invalid mask size %vx%v", bounds.Dx(), bounds.Dy())
+	}
+	mask := models.NewMask(int32(bounds.Dx()), int32(bounds.Dy()))
+	for y := 0; y < bounds.Dy(); y++ {
+		for x := 0; x < bounds.Dx(); x++ {
+			gray := color.Gray16Model.Convert(img.At(bounds.Min.X+x, bounds.Min.Y+y)).(color.Gray16)
+			_, _, _, alpha := img.At(bounds.Min.X+x, bounds.Min.Y+y).RGBA()
+			mask.Cells[y*bounds.Dx()+x] = alpha >= 0x8000 && gray.Y >= 0x8000
+		}
+	}
+	return mask, nil
+}
+
+// EncodeMaskPNG writes a 1-bit image, white for placeable cells and black for blocked ones
+func EncodeMaskPNG(w io.Writer, mask *models.Mask) error {
+	img := image.NewPaletted(image.Rect(0, 0, int(mask.Width), int(mask.Height)), color.Palette{color.Black, color.White})
+	for i, placeable := range mask.Cells {
+		if placeable {
+			img.Pix[i] = 1
+		}
+	}
+	return png.Encode(w, img)
+}
+
+// LoadMaskFile reads a mask in the format given by the file extension
+func LoadMaskFile(path string) (*models.Mask, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	switch strings.ToLower(filepath.Ext(path)) {
+	case MASK_EXT_RLE:
+		return DecodeMaskRLE(file)
+	case MASK_EXT_PNG:
+		return DecodeMaskPNG(file)
+	}
+	return nil, fmt.Errorf("unknown mask format %q", filepath.Ext(path))
+}
+
+// #endregion Mask Formats
@@ -0,0 +1,89 @@
+package catalogue
+
+import (
+	"bytes"
+	"canvas/models"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMaskRLERoundTrip(t *testing.T) {
+	checkerboard := models.NewMask(7, 6)
+	for i := range checkerboard.Cells {
+		checkerboard.Cells[i] = (i%7+i/7)%2 == 0
+	}
+	allPlaceable := models.NewMask(4, 3)
+	for i := range allPlaceable.Cells {
+		allPlaceable.Cells[i] = true
+	}
+	square := models.NewMask(5, 5)
+	for _, i := range []int{6, 7, 8, 11, 12, 13, 16, 17, 18} {
+		square.Cells[i] = true
+	}
+	lastCell := models.NewMask(3, 1)
+	lastCell.Cells[2] = true
+
+	tests := []struct {
+		name string
+		mask *models.Mask
+	}{
+		{name: "all blocked", mask: models.NewMask(3, 2)},
+		{name: "all placeable", mask: allPlaceable},
+		{name: "square", mask: square},
+		{name: "only the last cell", mask: lastCell},
+		{name: "more runs than fit on a line", mask: checkerboard},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var encoded bytes.Buffer
+			err := EncodeMaskRLE(&encoded, test.mask)
+			if err != nil {
+				t.Fatal(err)
+			}
+			decoded, err := DecodeMaskRLE(&encoded)
+			if err != nil {
+				t.Fatalf("decoding %q: %v", encoded.String(), err)
+			}
+			if !reflect.DeepEqual(decoded, test.mask) {
+				t.Fatalf("got %+v, want %+v", decoded, test.mask)
+			}
+		})
+	}
+}
+
+func TestDecodeMaskRLE(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    []bool
+		wantErr bool
+	}{
+		{name: "starts with a placeable run", input: "2 2\n0 3 1", want: []bool{true, true, true, false}},
+		{name: "runs over several lines", input: "2 2\n1\n2\n1\n", want: []bool{false, true, true, false}},
+		{name: "empty", input: "", wantErr: true},
+		{name: "no height", input: "2", wantErr: true},
+		{name: "zero width", input: "0 2\n", wantErr: true},
+		{name: "larger than a canvas", input: fmt.Sprintf("%d 1\n%d", models.MAX_CANVAS_SIZE+1, models.MAX_CANVAS_SIZE+1), wantErr: true},
+		{name: "not a number", input: "2 2\n1 x 2", wantErr: true},
+		{name: "negative run", input: "2 2\n5 -1", wantErr: true},
+		{name: "runs too short", input: "2 2\n1 2", wantErr: true},
+		{name: "runs too long", input: "2 2\n1 2 2", wantErr: true},
+		{name: "no runs", input: "2 2", wantErr: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mask, err := DecodeMaskRLE(strings.NewReader(test.input))
+			if test.wantErr {
+				if err == nil {
+					t.Fatalf("got %+v, want an error", mask)
+				}
+				return
+			}
+			if err != nil || !reflect.DeepEqual(mask.Cells, test.want) {
+				t.Fatalf("got %+v, %v, want cells %v", mask, err, test.want)
+			}
+		})
+	}
+}
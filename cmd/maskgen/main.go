@@ -0,0 +1,196 @@
+// Command maskgen turns an image into a placement mask for a canvas.
+//
+// The image is resized to the canvas size and every cell is thresholded on alpha or luminance.
+// PNG, JPEG and GIF images are read directly, SVGs have to be rasterised first (e.g. with rsvg-convert).
+//
+//	go run ./cmd/maskgen -in india.png -out masks/INDIA_CANVAS.rle -preview india_preview.png
+package main
+
+import (
+	"canvas/catalogue"
+	"canvas/models"
+	"flag"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Threshold Modes
+const (
+	MODE_ALPHA     = "alpha"
+	MODE_LUMINANCE = "luminance"
+)
+
+func main() {
+	in := flag.String("in", "", "source image (png, jpeg or gif)")
+	out := flag.String("out", "", "mask file to write, .rle or .png")
+	preview := flag.String("preview", "", "optional preview png to write")
+	width := flag.Int("width", models.DEFAULT_X_SIZE, "canvas width")
+	height := flag.Int("height", models.DEFAULT_Y_SIZE, "canvas height")
+	mode := flag.String("mode", MODE_ALPHA, "threshold on \"alpha\" or \"luminance\"")
+	threshold := flag.Int("threshold", 1, "cells at or above this value (0-255) are placeable")
+	invert := flag.Bool("invert", false, "swap placeable and blocked cells")
+	scale := flag.Int("scale", 4, "size of a cell in the preview, in pixels")
+	flag.Parse()
+
+	if *in == "" || *out == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+	if *width <= 0 || *height <= 0 || *width > models.MAX_CANVAS_SIZE || *height > models.MAX_CANVAS_SIZE {
+		log.Fatalf("canvas size must be between 1x1 and %vx%v", models.MAX_CANVAS_SIZE, models.MAX_CANVAS_SIZE)
+	}
+	if *mode != MODE_ALPHA && *mode != MODE_LUMINANCE {
+		log.Fatalf("unknown mode %q", *mode)
+	}
+	if *threshold < 0 || *threshold > 255 {
+		log.Fatalf("threshold must be between 0 and 255")
+	}
+	if strings.EqualFold(filepath.Ext(*in), ".svg") {
+		log.Fatalf("rasterise %v to png first, e.g. rsvg-convert -o image.png %v", *in, *in)
+	}
+
+	file, err := os.Open(*in)
+	if err != nil {
+		log.Fatal(err)
+	}
+	source, _, err := image.Decode(file)
+	file.Close()
+	if err != nil {
+		log.Fatalf("decoding %v: %v", *in, err)
+	}
+
+	resized := resize(source, *width, *height)
+	mask := makeMask(resized, *mode, uint8(*threshold), *invert)
+
+	err = writeMask(*out, mask)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if *preview != "" {
+		err = writePreview(*preview, resized, mask, *scale)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+	printStats(mask)
+}
+
+// resize averages every source pixel covered by a cell, so thin shapes are not lost when shrinking
+func resize(source image.Image, width int, height int) *image.NRGBA {
+	bounds := source.Bounds()
+	resized := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		y0 := bounds.Min.Y + y*bounds.Dy()/height
+		y1 := max(bounds.Min.Y+(y+1)*bounds.Dy()/height, y0+1)
+		for x := 0; x < width; x++ {
+			x0 := bounds.Min.X + x*bounds.Dx()/width
+			x1 := max(bounds.Min.X+(x+1)*bounds.Dx()/width, x0+1)
+			var r, g, b, a, count uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					pr, pg, pb, pa := source.At(sx, sy).RGBA()
+					r, g, b, a = r+uint64(pr), g+uint64(pg), b+uint64(pb), a+uint64(pa)
+					count++
+				}
+			}
+			// RGBA is premultiplied, go back to straight colour for the NRGBA cell
+			cell := color.NRGBA{A: uint8(a / count >> 8)}
+			if a > 0 {
+				cell.R = uint8(r * 0xffff / a >> 8)
+				cell.G = uint8(g * 0xffff / a >> 8)
+				cell.B = uint8(b * 0xffff / a >> 8)
+			}
+			resized.SetNRGBA(x, y, cell)
+		}
+	}
+	return resized
+}
+
+func makeMask(img *image.NRGBA, mode string, limit uint8, invert bool) *models.Mask {
+	bounds := img.Bounds()
+	mask := models.NewMask(int32(bounds.Dx()), int32(bounds.Dy()))
+	for y := 0; y < bounds.Dy(); y++ {
+		for x := 0; x < bounds.Dx(); x++ {
+			cell := img.NRGBAAt(x, y)
+			value := cell.A
+			if mode == MODE_LUMINANCE {
+				// transparent cells count as dark
+				gray := color.GrayModel.Convert(color.NRGBA{R: cell.R, G: cell.G, B: cell.B, A: 0xff}).(color.Gray)
+				value = uint8(uint16(gray.Y) * uint16(cell.A) / 0xff)
+			}
+			mask.Cells[y*bounds.Dx()+x] = (value >= limit) != invert
+		}
+	}
+	return mask
+}
+
+func writeMask(path string, mask *models.Mask) error {
+	var encode func(io.Writer, *models.Mask) error
+	switch strings.ToLower(filepath.Ext(path)) {
+	case catalogue.MASK_EXT_RLE:
+		encode = catalogue.EncodeMaskRLE
+	case catalogue.MASK_EXT_PNG:
+		encode = catalogue.EncodeMaskPNG
+	default:
+		return fmt.Errorf("unknown mask format %q, use %v or %v", filepath.Ext(path), catalogue.MASK_EXT_RLE, catalogue.MASK_EXT_PNG)
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	return encode(file, mask)
+}
+
+// writePreview draws placeable cells in the colour of the resized image and blocked cells as a dark checkerboard
+func writePreview(path string, resized *image.NRGBA, mask *models.Mask, scale int) error {
+	if scale < 1 {
+		scale = 1
+	}
+	preview := image.NewNRGBA(image.Rect(0, 0, int(mask.Width)*scale, int(mask.Height)*scale))
+	for y := 0; y < int(mask.Height); y++ {
+		for x := 0; x < int(mask.Width); x++ {
+			cell := color.NRGBA{R: 0x30, G: 0x30, B: 0x30, A: 0xff}
+			if (x+y)%2 == 0 {
+				cell = color.NRGBA{R: 0x40, G: 0x40, B: 0x40, A: 0xff}
+			}
+			if mask.CanPlace(int32(x), int32(y)) {
+				cell = resized.NRGBAAt(x, y)
+				cell.A = 0xff
+			}
+			for py := 0; py < scale; py++ {
+				for px := 0; px < scale; px++ {
+					preview.SetNRGBA(x*scale+px, y*scale+py, cell)
+				}
+			}
+		}
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	return png.Encode(file, preview)
+}
+
+func printStats(mask *models.Mask) {
+	placeable := mask.PlaceableCount()
+	total := len(mask.Cells)
+	runs := 1
+	for i := 1; i < total; i++ {
+		if mask.Cells[i] != mask.Cells[i-1] {
+			runs++
+		}
+	}
+	fmt.Printf("size:       %vx%v\n", mask.Width, mask.Height)
+	fmt.Printf("placeable:  %v (%.1f%%)\n", placeable, float64(placeable)*100/float64(total))
+	fmt.Printf("blocked:    %v\n", total-placeable)
+	fmt.Printf("rle runs:   %v\n", runs)
+}
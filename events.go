@@ -0,0 +1,197 @@
+package main
+
+import (
+	"canvas/catalogue"
+	"canvas/connections"
+	"canvas/functions"
+	"canvas/models"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// #region Event Stream
+
+var streamClients = &sync.Map{}
+
+// streamUpdates serves the updates of a canvas as Server-Sent Events for clients that cannot use websockets.
+// Every event id is a sequence number, reconnecting with Last-Event-ID resumes after it. ?snapshot=true starts
+// with a snapshot event holding the whole canvas, which is also sent when the updates to resume from are gone.
+// Access and rate limits are those of the websocket handshake.
+func streamUpdates(w http.ResponseWriter, r *http.Request) {
+	canvasIdentifier := r.PathValue("canvasIdentifier")
+	if !functions.CanvasExists(catalogue.CANVAS_LIST, canvasIdentifier) {
+		writeError(w, http.StatusNotFound, "Invalid Canvas Identifier")
+		return
+	}
+	protocol, err := functions.NegotiateProtocol("", r.URL.Query().Get("version"), models.FORMAT_JSON)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	//#region User Auth
+	// same checks as the websocket handshake, streams without a user id and token are spectators
+	userId := r.URL.Query().Get("userId")
+	xAuthToken := r.Header.Get("X-Auth-Token")
+	isSpectator := userId == "" && xAuthToken == ""
+	if isSpectator && !models.ALLOW_SPECTATORS {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	if rateLimited(w, functions.UpgradeBuckets(functions.ClientIP(r), "")) {
+		return
+	}
+	if !isSpectator {
+		if !functions.VerifyUser(userId, xAuthToken) {
+			writeError(w, http.StatusUnauthorized, "Unauthorized")
+			return
+		}
+		if rateLimited(w, functions.UpgradeBuckets("", userId)) {
+			return
+		}
+	}
+	//#endregion User Auth
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeError(w, http.StatusInternalServerError, "Streaming unsupported")
+		return
+	}
+
+	client := &models.StreamClient{
+		CanvasIdentifier: canvasIdentifier,
+		Protocol:         protocol,
+		Events:           make(chan models.StreamEvent, models.STREAM_CLIENT_BUFFER),
+	}
+	lastId := r.Header.Get("Last-Event-ID")
+	if lastId == "" {
+		// EventSource only sends Last-Event-ID on reconnects
+		lastId = r.URL.Query().Get("lastEventId")
+	}
+	lastId, snapshot, backlog, err := openUpdateStream(client, lastId, r.URL.Query().Get("snapshot") == "true")
+	defer streamClients.Delete(client)
+	if err != nil {
+		log.Println("ERR76: ", err)
+		writeError(w, http.StatusInternalServerError, "Error reading updates!")
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	// stop nginx from buffering the stream
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	if snapshot != nil {
+		data, err := json.Marshal(snapshot)
+		if err != nil {
+			log.Println("ERR77: ", err)
+			return
+		}
+		fmt.Fprintf(w, "id: %s\nevent: snapshot\ndata: %s\n\n", lastId, data)
+	}
+	for _, event := range backlog {
+		encoder, err := functions.NewEventEncoder(event.Payload)
+		if err != nil {
+			log.Println("ERR77: ", err)
+			continue
+		}
+		data, err := encoder.Encode(protocol, "")
+		if err != nil {
+			log.Println("ERR77: ", err)
+			continue
+		}
+		fmt.Fprintf(w, "id: %s\ndata: %s\n\n", event.Id, data)
+	}
+	flusher.Flush()
+
+	ticker := time.NewTicker(models.PING_INTERVAL * time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case event, open := <-client.Events:
+			if !open {
+				// too slow to keep up, the client resumes from lastId when it reconnects
+				return
+			}
+			if functions.CompareUpdateIds(event.Id, lastId) <= 0 {
+				continue
+			}
+			fmt.Fprintf(w, "id: %s\ndata: %s\n\n", event.Id, event.Payload)
+			lastId = event.Id
+			flusher.Flush()
+		case <-ticker.C:
+			fmt.Fprint(w, ": ping\n\n")
+			flusher.Flush()
+		}
+	}
+}
+
+// openUpdateStream registers a stream client resuming after lastId, before reading the stream so no update falls
+// between the backlog and the live updates. It returns the updates to send first and the sequence number of the
+// last of them. A snapshot is returned when asked for without lastId, or when the updates after lastId are gone.
+func openUpdateStream(client *models.StreamClient, lastId string, withSnapshot bool) (string, *models.CanvasRegion, []models.StreamEvent, error) {
+	streamClients.Store(client, true)
+	if lastId != "" {
+		backlog, complete, err := functions.UpdatesSince(client.CanvasIdentifier, lastId, connections.RedisClient)
+		if err != nil {
+			log.Println("ERR75: ", err)
+		}
+		if complete {
+			if len(backlog) > 0 {
+				lastId = backlog[len(backlog)-1].Id
+			}
+			return lastId, nil, backlog, nil
+		}
+		withSnapshot = true
+	}
+	lastId, err := functions.LatestUpdateId(client.CanvasIdentifier, connections.RedisClient)
+	if err != nil || !withSnapshot {
+		return lastId, nil, nil, err
+	}
+	pixels, err := functions.GetCanvas(client.CanvasIdentifier, connections.RedisClient)
+	if err != nil && err != redis.Nil {
+		return "", nil, nil, err
+	}
+	width, height := functions.CanvasSize(client.CanvasIdentifier)
+	return lastId, &models.CanvasRegion{Width: width, Height: height, Pixels: pixels}, nil, nil
+}
+
+// forwardStreamUpdate encodes an update once per protocol for the SSE clients of its canvas, disconnecting
+// the clients whose buffer is full
+func forwardStreamUpdate(canvasIdentifier string, event models.StreamEvent) {
+	encoder, err := functions.NewEventEncoder(event.Payload)
+	if err != nil {
+		log.Println("ERR78: ", err)
+		return
+	}
+	streamClients.Range(func(key, value interface{}) bool {
+		client := key.(*models.StreamClient)
+		if client.CanvasIdentifier != canvasIdentifier {
+			return true
+		}
+		data, err := encoder.Encode(client.Protocol, "")
+		if err != nil {
+			log.Println("ERR78: ", err)
+			return true
+		}
+		select {
+		case client.Events <- models.StreamEvent{Id: event.Id, Payload: data}:
+		default:
+			if _, loaded := streamClients.LoadAndDelete(client); loaded {
+				close(client.Events)
+			}
+		}
+		return true
+	})
+}
+
+// #endregion Event Stream
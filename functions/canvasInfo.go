@@ -0,0 +1,111 @@
+package functions
+
+import (
+	"canvas/catalogue"
+	"canvas/models"
+	canvas "canvas/proto"
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/redis/go-redis/v9"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// #region Canvas Info
+
+func GetCanvasInfo(canvasIdentifier string) models.CanvasInfo {
+	width, height := CanvasSize(canvasIdentifier)
+	phase := CanvasLifecycle(canvasIdentifier)
+	config := catalogue.CANVAS_CONFIGS[canvasIdentifier]
+	return models.CanvasInfo{
+		Identifier: canvasIdentifier,
+		Width:      width,
+		Height:     height,
+		UseCredits: config.UseCredits,
+		Palette:    catalogue.CanvasPalette(canvasIdentifier),
+		Regions:    config.Regions,
+		State:      phase.State,
+		StartsAt:   phase.StartsAt,
+		EndsAt:     phase.EndsAt,
+	}
+}
+
+// ConfigResponse answers GET_CONFIG for a canvas. userId is empty for spectators, credits is nil unless
+// the canvas uses credits and the balance of the user could be read.
+func ConfigResponse(canvasIdentifier string, userId string, redisClient *redis.Client) (*canvas.ResponseMessage, *models.CreditBalance) {
+	canvasWidth, canvasHeight := CanvasSize(canvasIdentifier)
+	response := &canvas.ResponseMessage{
+		MessageType:       models.Success,
+		CanvasWidth:       canvasWidth,
+		CanvasHeight:      canvasHeight,
+		UserCooldown:      models.USER_COOLDOWN_PERIOD,
+		PixelCooldown:     models.PIXEL_COOLDOWN_PERIOD,
+		PingInterval:      models.PING_INTERVAL,
+		DisconnectTimeout: models.DISCONNECT_AFTER_SECS,
+		Palette:           PaletteMessage(canvasIdentifier),
+		CanvasIdentifier:  canvasIdentifier,
+	}
+	phase := CanvasLifecycle(canvasIdentifier)
+	response.CanvasState = phase.State
+	response.StateStartsAt = phase.StartsAt
+	response.StateEndsAt = phase.EndsAt
+	if !UsesCredits(canvasIdentifier) || userId == "" {
+		return response, nil
+	}
+	credits, err := GetCredits(userId, redisClient)
+	if err != nil {
+		log.Println("Error getting credits:", err)
+		return response, nil
+	}
+	response.PixelsAvailable = int32(credits.Balance)
+	response.NextPixelAt = credits.NextRefillAt
+	return response, &credits
+}
+
+// GetRegion reads a rectangle of the canvas, one GETRANGE per row
+func GetRegion(canvasIdentifier string, x int32, y int32, width int32, height int32, redisClient *redis.Client) (models.CanvasRegion, error) {
+	region := models.CanvasRegion{X: x, Y: y, Width: width, Height: height}
+	canvasWidth, canvasHeight := CanvasSize(canvasIdentifier)
+	// compared without adding to the offsets, which can overflow int32
+	if x < 0 || y < 0 || width < 1 || height < 1 || x >= canvasWidth || y >= canvasHeight || width > canvasWidth-x || height > canvasHeight-y {
+		return region, fmt.Errorf("region is outside of the %vx%v canvas", canvasWidth, canvasHeight)
+	}
+	if int64(width)*int64(height) > int64(models.MAX_REGION_PIXELS) {
+		return region, fmt.Errorf("a region can hold at most %v pixels", models.MAX_REGION_PIXELS)
+	}
+	pipe := redisClient.Pipeline()
+	rows := make([]*redis.StringCmd, height)
+	for row := int32(0); row < height; row++ {
+		start := int64((y+row)*canvasWidth + x)
+		rows[row] = pipe.GetRange(context.TODO(), canvasIdentifier, start, start+int64(width)-1)
+	}
+	_, err := pipe.Exec(context.TODO())
+	if err != nil && err != redis.Nil {
+		return region, err
+	}
+	region.Pixels = make([]int32, width*height)
+	for row, cmd := range rows {
+		value := cmd.Val()
+		for column := 0; column < len(value) && column < int(width); column++ {
+			region.Pixels[row*int(width)+column] = int32(int8(value[column]))
+		}
+	}
+	return region, nil
+}
+
+// GetPixelData looks up who placed a pixel last and when, found is false for pixels nobody placed yet
+func GetPixelData(pixelId int32, canvasIdentifier string, mongoClient *mongo.Client) (models.PixelData, bool, error) {
+	var pixelData models.PixelData
+	err := mongoClient.Database("canvas").Collection(canvasIdentifier).FindOne(context.TODO(), bson.M{"pixelId": pixelId}).Decode(&pixelData)
+	if err == mongo.ErrNoDocuments {
+		return models.PixelData{PixelId: pixelId}, false, nil
+	}
+	if err != nil {
+		return pixelData, false, err
+	}
+	return pixelData, true, nil
+}
+
+// #endregion Canvas Info
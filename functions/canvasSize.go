@@ -0,0 +1,354 @@
+package functions
+
+import (
+	"canvas/catalogue"
+	"canvas/models"
+	canvas "canvas/proto"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"google.golang.org/protobuf/proto"
+)
+
+// #region Canvas Size
+
+// canvasSizes caches the width and height of every canvas, see LoadCanvasSizes
+var canvasSizes sync.Map
+
+func canvasSizeKey(canvasIdentifier string) string {
+	return "CANVAS_SIZE:" + canvasIdentifier
+}
+
+func resizeLockKey(canvasIdentifier string) string {
+	return "RESIZING:" + canvasIdentifier
+}
+
+// LoadCanvasSizes reads the size of every canvas from Redis, canvases never resized use the default size
+func LoadCanvasSizes(redisClient *redis.Client) error {
+	for _, canvasIdentifier := range catalogue.CANVAS_LIST {
+		width, height, err := readCanvasSize(canvasIdentifier, redisClient)
+		if err != nil {
+			return err
+		}
+		SetCanvasSize(canvasIdentifier, width, height)
+	}
+	return nil
+}
+
+func readCanvasSize(canvasIdentifier string, redisClient *redis.Client) (int32, int32, error) {
+	size, err := redisClient.HMGet(context.TODO(), canvasSizeKey(canvasIdentifier), "width", "height").Result()
+	if err != nil {
+		return 0, 0, err
+	}
+	width, height := int32(models.DEFAULT_X_SIZE), int32(models.DEFAULT_Y_SIZE)
+	if size[0] != nil && size[1] != nil {
+		_, err := fmt.Sscan(size[0].(string)+" "+size[1].(string), &width, &height)
+		if err != nil {
+			return 0, 0, err
+		}
+	}
+	return width, height, nil
+}
+
+func SetCanvasSize(canvasIdentifier string, width int32, height int32) {
+	canvasSizes.Store(canvasIdentifier, [2]int32{width, height})
+}
+
+// CanvasSize returns the cached width and height of a canvas
+func CanvasSize(canvasIdentifier string) (int32, int32) {
+	size, found := canvasSizes.Load(canvasIdentifier)
+	if !found {
+		return models.DEFAULT_X_SIZE, models.DEFAULT_Y_SIZE
+	}
+	return size.([2]int32)[0], size.([2]int32)[1]
+}
+
+// RemapPixelId moves a pixel index from a canvas of oldWidth to one of newWidth keeping its x/y position
+func RemapPixelId(pixelId int32, oldWidth int32, newWidth int32) int32 {
+	return (pixelId/oldWidth)*newWidth + pixelId%oldWidth
+}
+
+// ErrCanvasResizing is returned by pixel writes while the canvas is being resized or when it no longer has the size
+// the pixel ids were checked against
+var ErrCanvasResizing = errors.New("canvas is being resized")
+
+// pixelWriteTimeout is how long a placement may take before ResizeCanvas stops waiting for it
+const pixelWriteTimeout = 30 * time.Second
+
+func pixelWritesKey(canvasIdentifier string) string {
+	return "PIXEL_WRITES:" + canvasIdentifier
+}
+
+// Both scripts refuse with 'RESIZING' while the canvas is locked by KEYS[2], unless ARGV[3] is a pixel write
+// that began before the lock and is still in KEYS[4], and with 'RESIZED' when its size in KEYS[3] is no longer
+// ARGV[1] x ARGV[2].
+const canvasSizeGuard = `
+local width, height = tonumber(ARGV[1]), tonumber(ARGV[2])
+if redis.call('EXISTS', KEYS[2]) == 1 and not redis.call('ZSCORE', KEYS[4], ARGV[3]) then
+	return 'RESIZING'
+end
+local size = redis.call('HMGET', KEYS[3], 'width', 'height')
+if (tonumber(size[1]) or width) ~= width or (tonumber(size[2]) or height) ~= height then
+	return 'RESIZED'
+end
+`
+
+// beginPixelWriteScript adds ARGV[3] to the pixel writes in progress in KEYS[4], expiring after ARGV[4] ms
+var beginPixelWriteScript = redis.NewScript(canvasSizeGuard + `
+local time = redis.call('TIME')
+local now = tonumber(time[1]) * 1000 + math.floor(tonumber(time[2]) / 1000)
+redis.call('ZADD', KEYS[4], now + tonumber(ARGV[4]), ARGV[3])
+redis.call('PEXPIRE', KEYS[4], tonumber(ARGV[4]))
+return 'OK'
+`)
+
+// writePixelsScript sets the pixels in KEYS[1] to the ARGV[4..] pairs of pixel id and colour.
+// Returns the previous colours.
+var writePixelsScript = redis.NewScript(canvasSizeGuard + `
+local previous = {}
+for i = 4, #ARGV, 2 do
+	previous[#previous + 1] = redis.call('BITFIELD', KEYS[1], 'SET', 'i8', '#' .. ARGV[i], ARGV[i + 1])[1]
+end
+return previous
+`)
+
+// pendingPixelWritesScript drops the expired pixel writes in KEYS[1] and counts the others
+var pendingPixelWritesScript = redis.NewScript(`
+local time = redis.call('TIME')
+local now = tonumber(time[1]) * 1000 + math.floor(tonumber(time[2]) / 1000)
+redis.call('ZREMRANGEBYSCORE', KEYS[1], '-inf', now)
+return redis.call('ZCARD', KEYS[1])
+`)
+
+// runSizeGuarded runs a script starting with canvasSizeGuard against the cached size of the canvas.
+// A 'RESIZED' refusal means the cached size is stale, it is read again for the next write.
+func runSizeGuarded(script *redis.Script, canvasIdentifier string, writeId string, args []interface{}, redisClient *redis.Client) (interface{}, error) {
+	width, height := CanvasSize(canvasIdentifier)
+	keys := []string{canvasIdentifier, resizeLockKey(canvasIdentifier), canvasSizeKey(canvasIdentifier), pixelWritesKey(canvasIdentifier)}
+	result, err := script.Run(context.TODO(), redisClient, keys, append([]interface{}{width, height, writeId}, args...)...).Result()
+	if err != nil {
+		return nil, err
+	}
+	switch result {
+	case "RESIZING":
+		return nil, ErrCanvasResizing
+	case "RESIZED":
+		width, height, err := readCanvasSize(canvasIdentifier, redisClient)
+		if err != nil {
+			return nil, err
+		}
+		SetCanvasSize(canvasIdentifier, width, height)
+		return nil, ErrCanvasResizing
+	}
+	return result, nil
+}
+
+// PixelWrite is a placement or undo in progress on a canvas. ResizeCanvas waits for every PixelWrite to End
+// before moving any pixel, so the history and cooldowns written with the old pixel ids are remapped too.
+type PixelWrite struct {
+	canvasIdentifier string
+	id               string
+	redisClient      *redis.Client
+}
+
+// BeginPixelWrite registers a pixel write, or returns ErrCanvasResizing while the canvas is being resized
+func BeginPixelWrite(canvasIdentifier string, redisClient *redis.Client) (*PixelWrite, error) {
+	write := &PixelWrite{canvasIdentifier: canvasIdentifier, id: NewSessionId(), redisClient: redisClient}
+	_, err := runSizeGuarded(beginPixelWriteScript, canvasIdentifier, write.id, []interface{}{pixelWriteTimeout.Milliseconds()}, redisClient)
+	if err != nil {
+		return nil, err
+	}
+	return write, nil
+}
+
+func (w *PixelWrite) End() {
+	err := w.redisClient.ZRem(context.TODO(), pixelWritesKey(w.canvasIdentifier), w.id).Err()
+	if err != nil {
+		log.Println("Error ending pixel write:", err)
+	}
+}
+
+// WritePixels sets the given pixels in one step with the resize, so no pixel id computed for the old size
+// lands on the resized canvas. Returns the previous colour of every pixel.
+func WritePixels(canvasIdentifier string, pixelIds []int32, colors []int32, redisClient *redis.Client) ([]int64, error) {
+	return writePixels(canvasIdentifier, "", pixelIds, colors, redisClient)
+}
+
+// WritePixels sets pixels as part of the pixel write. A resize locking the canvas meanwhile waits for it to end,
+// so only a stale canvas size refuses the write.
+func (w *PixelWrite) WritePixels(pixelIds []int32, colors []int32) ([]int64, error) {
+	return writePixels(w.canvasIdentifier, w.id, pixelIds, colors, w.redisClient)
+}
+
+func writePixels(canvasIdentifier string, writeId string, pixelIds []int32, colors []int32, redisClient *redis.Client) ([]int64, error) {
+	args := []interface{}{}
+	for i, pixelId := range pixelIds {
+		args = append(args, pixelId, colors[i])
+	}
+	result, err := runSizeGuarded(writePixelsScript, canvasIdentifier, writeId, args, redisClient)
+	if err != nil {
+		return nil, err
+	}
+	previousColors := make([]int64, len(result.([]interface{})))
+	for i, previousColor := range result.([]interface{}) {
+		previousColors[i] = previousColor.(int64)
+	}
+	return previousColors, nil
+}
+
+// waitForPixelWrites waits until the pixel writes that began before the resize lock was taken have ended
+func waitForPixelWrites(canvasIdentifier string, redisClient *redis.Client) error {
+	deadline := time.Now().Add(pixelWriteTimeout)
+	for {
+		pending, err := pendingPixelWritesScript.Run(context.TODO(), redisClient, []string{pixelWritesKey(canvasIdentifier)}).Int64()
+		if err != nil {
+			return err
+		}
+		if pending == 0 {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("%v placements on %v did not finish", pending, canvasIdentifier)
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
+
+func IsResizing(canvasIdentifier string, redisClient *redis.Client) (bool, error) {
+	count, err := redisClient.Exists(context.TODO(), resizeLockKey(canvasIdentifier)).Result()
+	return count > 0, err
+}
+
+// resizeBitfieldScript copies every row of the bitfield in KEYS[1] into a wider and taller one
+// and stores the new size in KEYS[2], as long as the size is still ARGV[1] x ARGV[2].
+var resizeBitfieldScript = redis.NewScript(`
+local oldWidth, oldHeight = tonumber(ARGV[1]), tonumber(ARGV[2])
+local newWidth, newHeight = tonumber(ARGV[3]), tonumber(ARGV[4])
+local size = redis.call('HMGET', KEYS[2], 'width', 'height')
+if (tonumber(size[1]) or oldWidth) ~= oldWidth or (tonumber(size[2]) or oldHeight) ~= oldHeight then
+	return redis.error_reply('canvas size changed')
+end
+local old = redis.call('GET', KEYS[1]) or ''
+local rows = {}
+for y = 0, oldHeight - 1 do
+	local row = string.sub(old, y * oldWidth + 1, (y + 1) * oldWidth)
+	rows[#rows + 1] = row .. string.rep('\0', newWidth - #row)
+end
+rows[#rows + 1] = string.rep('\0', (newHeight - oldHeight) * newWidth)
+redis.call('SET', KEYS[1], table.concat(rows))
+redis.call('HSET', KEYS[2], 'width', newWidth, 'height', newHeight)
+return 1
+`)
+
+// ResizeCanvas grows a canvas keeping every pixel at its x/y position in Redis and Mongo,
+// then publishes the new size on the canvasEvents channel. Placements are refused from the moment the canvas
+// is locked and the ones already running are waited for, so none of them writes an old pixel id after the remap.
+func ResizeCanvas(canvasIdentifier string, width int32, height int32, redisClient *redis.Client, mongoClient *mongo.Client) error {
+	locked, err := redisClient.SetNX(context.TODO(), resizeLockKey(canvasIdentifier), 1, 5*time.Minute).Result()
+	if err != nil {
+		return err
+	}
+	if !locked {
+		return fmt.Errorf("%v is already being resized", canvasIdentifier)
+	}
+	defer redisClient.Del(context.TODO(), resizeLockKey(canvasIdentifier))
+
+	oldWidth, oldHeight, err := readCanvasSize(canvasIdentifier, redisClient)
+	if err != nil {
+		return err
+	}
+	if width < oldWidth || height < oldHeight {
+		return fmt.Errorf("canvas can only grow, it is %vx%v", oldWidth, oldHeight)
+	}
+	if width > models.MAX_CANVAS_SIZE || height > models.MAX_CANVAS_SIZE {
+		return fmt.Errorf("canvas can be at most %vx%v", models.MAX_CANVAS_SIZE, models.MAX_CANVAS_SIZE)
+	}
+	if width == oldWidth && height == oldHeight {
+		return nil
+	}
+
+	err = waitForPixelWrites(canvasIdentifier, redisClient)
+	if err != nil {
+		return err
+	}
+	err = resizeBitfieldScript.Run(context.TODO(), redisClient, []string{canvasIdentifier, canvasSizeKey(canvasIdentifier)},
+		oldWidth, oldHeight, width, height).Err()
+	if err != nil {
+		return err
+	}
+	// the new size is stored from here on, every instance has to learn it even when remapping fails
+	remapErr := remapPixelIds(canvasIdentifier, oldWidth, width, redisClient, mongoClient)
+	return errors.Join(remapErr, publishCanvasSize(canvasIdentifier, width, height, redisClient))
+}
+
+// remapPixelIds moves the pixel history to the new width and drops the pixel cooldowns and undo records of the old one
+func remapPixelIds(canvasIdentifier string, oldWidth int32, width int32, redisClient *redis.Client, mongoClient *mongo.Client) error {
+	if width == oldWidth {
+		return nil
+	}
+
+	//#region remap mongo
+	// same as RemapPixelId, run inside mongo
+	remap := bson.A{bson.M{"$set": bson.M{"pixelId": bson.M{"$toInt": bson.M{"$add": bson.A{
+		bson.M{"$multiply": bson.A{bson.M{"$floor": bson.M{"$divide": bson.A{"$pixelId", oldWidth}}}, width}},
+		bson.M{"$mod": bson.A{"$pixelId", oldWidth}},
+	}}}}}}
+	_, err := mongoClient.Database("canvas").Collection(canvasIdentifier).UpdateMany(context.TODO(), bson.M{}, remap)
+	if err != nil {
+		return fmt.Errorf("bitfield was resized but pixel owners were not remapped: %w", err)
+	}
+	//#endregion remap mongo
+
+	//#region drop pixel cooldowns and undo records
+	// the pixel ids in the keys no longer match the pixels they were set for
+	err = deleteKeys(fmt.Sprintf("COOLDOWN:%s:PIXEL:*", canvasIdentifier), redisClient)
+	if err != nil {
+		return fmt.Errorf("canvas was resized but pixel cooldowns were not dropped: %w", err)
+	}
+	err = deleteKeys(undoKey(canvasIdentifier, "*"), redisClient)
+	if err != nil {
+		return fmt.Errorf("canvas was resized but undo records were not dropped: %w", err)
+	}
+	//#endregion drop pixel cooldowns and undo records
+	return nil
+}
+
+func deleteKeys(pattern string, redisClient *redis.Client) error {
+	iter := redisClient.Scan(context.TODO(), 0, pattern, 1000).Iterator()
+	for iter.Next(context.TODO()) {
+		err := redisClient.Del(context.TODO(), iter.Val()).Err()
+		if err != nil {
+			return err
+		}
+	}
+	return iter.Err()
+}
+
+// publishCanvasSize caches the new size and tells the other instances and the clients about it
+func publishCanvasSize(canvasIdentifier string, width int32, height int32, redisClient *redis.Client) error {
+	SetCanvasSize(canvasIdentifier, width, height)
+	message := &canvas.ResponseMessage{
+		MessageType:      models.CanvasResized,
+		CanvasIdentifier: canvasIdentifier,
+		CanvasWidth:      width,
+		CanvasHeight:     height,
+	}
+	messageByte, err := proto.Marshal(message)
+	if err != nil {
+		return err
+	}
+	pipe := redisClient.Pipeline()
+	pipe.Publish(context.TODO(), "canvasEvents", messageByte)
+	StreamUpdate(pipe, canvasIdentifier, messageByte)
+	_, err = pipe.Exec(context.TODO())
+	return err
+}
+
+// #endregion Canvas Size
@@ -0,0 +1,125 @@
+package functions
+
+import (
+	"canvas/catalogue"
+	"canvas/models"
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// #region Credits
+
+// spendCreditsScript refills the balance in KEYS[1] by one credit per ARGV[2] ms up to ARGV[1],
+// then spends ARGV[3] credits when the balance allows it. A cost of 0 only reads the balance.
+// Returns {spent (1/0), balance, next refill unix ms or 0 when full}.
+var spendCreditsScript = redis.NewScript(`
+local time = redis.call('TIME')
+local now = tonumber(time[1]) * 1000 + math.floor(tonumber(time[2]) / 1000)
+local cap = tonumber(ARGV[1])
+local interval = tonumber(ARGV[2])
+local cost = tonumber(ARGV[3])
+local state = redis.call('HMGET', KEYS[1], 'balance', 'ts')
+local balance = tonumber(state[1]) or cap
+local ts = tonumber(state[2]) or now
+local gained = math.floor((now - ts) / interval)
+balance = math.min(cap, balance + gained)
+ts = ts + gained * interval
+if balance >= cap then
+	ts = now
+end
+local spent = 0
+if cost > 0 and balance >= cost then
+	balance = balance - cost
+	spent = 1
+end
+redis.call('HSET', KEYS[1], 'balance', balance, 'ts', ts)
+redis.call('PEXPIRE', KEYS[1], (cap - balance + 1) * interval)
+local nextRefill = 0
+if balance < cap then
+	nextRefill = ts + interval
+end
+return {spent, balance, nextRefill}
+`)
+
+// refundCreditsScript gives ARGV[2] credits back to the balance in KEYS[1] without going over ARGV[1]
+var refundCreditsScript = redis.NewScript(`
+local cap = tonumber(ARGV[1])
+local balance = tonumber(redis.call('HGET', KEYS[1], 'balance')) or cap
+balance = math.min(cap, balance + tonumber(ARGV[2]))
+redis.call('HSET', KEYS[1], 'balance', balance)
+return balance
+`)
+
+func creditsKey(userId string) string {
+	return "CREDITS:" + userId
+}
+
+func UsesCredits(canvasIdentifier string) bool {
+	return catalogue.CANVAS_CONFIGS[canvasIdentifier].UseCredits
+}
+
+// SpendCredits takes cost credits from the user balance if it has enough of them.
+// The balance is capped at the user's quota, see GetPixelsAvailable. Without a quota nothing is spent,
+// the script would otherwise cap the stored balance at 0.
+func SpendCredits(userId string, cost int, redisClient *redis.Client) (models.CreditBalance, error) {
+	pixelsAvailable, err := GetPixelsAvailable(userId)
+	if err != nil {
+		return models.CreditBalance{}, fmt.Errorf("quota unavailable: %w", err)
+	}
+	return runSpendCredits(userId, pixelsAvailable, cost, redisClient)
+}
+
+// GetCredits refills and reads the user balance. Without a quota the stored balance is returned untouched.
+func GetCredits(userId string, redisClient *redis.Client) (models.CreditBalance, error) {
+	pixelsAvailable, err := GetPixelsAvailable(userId)
+	if err != nil {
+		log.Println("Error getting pixels available:", err)
+		return storedCredits(userId, redisClient)
+	}
+	return runSpendCredits(userId, pixelsAvailable, 0, redisClient)
+}
+
+func runSpendCredits(userId string, pixelsAvailable uint16, cost int, redisClient *redis.Client) (models.CreditBalance, error) {
+	var credits models.CreditBalance
+	result, err := spendCreditsScript.Run(context.TODO(), redisClient, []string{creditsKey(userId)},
+		pixelsAvailable, models.CREDIT_REFILL_SECS*1000, cost).Int64Slice()
+	if err != nil {
+		return credits, err
+	}
+	credits.Spent = result[0] == 1
+	credits.Balance = uint16(result[1])
+	credits.NextRefillAt = result[2]
+	return credits, nil
+}
+
+// storedCredits reads the balance as last written, without refilling it
+func storedCredits(userId string, redisClient *redis.Client) (models.CreditBalance, error) {
+	var credits models.CreditBalance
+	balance, err := redisClient.HGet(context.TODO(), creditsKey(userId), "balance").Result()
+	if err != nil {
+		return credits, err
+	}
+	value, err := strconv.ParseUint(balance, 10, 16)
+	if err != nil {
+		return credits, err
+	}
+	credits.Balance = uint16(value)
+	return credits, nil
+}
+
+// RefundCredits gives back credits spent on a placement that did not go through, up to the user's quota.
+// Without a quota the refund is capped at CREDIT_CAP, the credits were already taken from the user.
+func RefundCredits(userId string, amount int, redisClient *redis.Client) error {
+	pixelsAvailable, err := GetPixelsAvailable(userId)
+	if err != nil {
+		log.Println("Error getting pixels available:", err)
+		pixelsAvailable = uint16(models.CREDIT_CAP)
+	}
+	return refundCreditsScript.Run(context.TODO(), redisClient, []string{creditsKey(userId)}, pixelsAvailable, amount).Err()
+}
+
+// #endregion Credits
@@ -5,14 +5,11 @@ import (
 	"canvas/models"
 	canvas "canvas/proto"
 	"context"
-	"encoding/json"
 	"fmt"
-	"io"
 	"log"
-	"net/http"
 	"time"
 
-	"github.com/dgrijalva/jwt-go"
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/redis/go-redis/v9"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
@@ -35,8 +32,8 @@ func VerifyUser(userId string, authToken string) bool {
 
 func DecodeJWT(tokenString string) (*jwt.Token, error) {
 	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-		return []byte("xxx"), nil
-	})
+		return []byte(models.JWT_SECRET), nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}))
 	if err != nil {
 		fmt.Println(err)
 		return nil, err
@@ -48,26 +45,27 @@ func DecodeJWT(tokenString string) (*jwt.Token, error) {
 
 // #region Verify Message
 func VerifyMessage(messageType int32) bool {
-	if messageType < 0 || messageType > 3 {
-		return false
+	for _, validType := range models.MESSAGE_TYPES {
+		if messageType == validType {
+			return true
+		}
 	}
-	return true
+	return false
 }
 
 func VerifyPlaceTileMessage(pixelId, color int32, canvasIdentifier string) bool {
 	validPixelId := false
 	validColor := false
-	if pixelId >= 0 || pixelId <= ((models.DEFAULT_X_SIZE*models.DEFAULT_Y_SIZE)-1) {
+	width, height := CanvasSize(canvasIdentifier)
+	if pixelId >= 0 && pixelId <= ((width*height)-1) {
 		validPixelId = true
 	}
-	if canvasIdentifier == catalogue.INDIA_CANVAS {
-		if catalogue.INDIA_CANVAS_DATA[pixelId] == models.CAN_PLACE_TILE {
-			validPixelId = true
-		} else {
-			validPixelId = false
-		}
+	mask := catalogue.CanvasMask(canvasIdentifier)
+	if validPixelId && mask != nil {
+		// cells outside of the mask, e.g. after a resize, are not placeable
+		validPixelId = mask.CanPlace(pixelId%width, pixelId/width)
 	}
-	if color >= 1 || color <= 10 {
+	if color >= 1 && int(color) <= len(catalogue.CanvasPalette(canvasIdentifier)) {
 		validColor = true
 	}
 	return validPixelId && validColor
@@ -86,9 +84,11 @@ func MakeDefaultCanvas(redisClient *redis.Client) error {
 	return nil
 }
 
+// MakeCanvas allocates the bitfield of a canvas without touching pixels that are already set
 func MakeCanvas(redisClient *redis.Client, canvasIdentifier string) error {
-	pixelID := (models.DEFAULT_X_SIZE * models.DEFAULT_Y_SIZE) - 1
-	_, err := redisClient.Do(context.TODO(), "BITFIELD", canvasIdentifier, "SET", "i8", "#"+fmt.Sprint(pixelID), fmt.Sprint(0)).Result()
+	width, height := CanvasSize(canvasIdentifier)
+	pixelID := (width * height) - 1
+	_, err := redisClient.Do(context.TODO(), "BITFIELD", canvasIdentifier, "INCRBY", "i8", "#"+fmt.Sprint(pixelID), fmt.Sprint(0)).Result()
 	if err != nil {
 		return err
 	}
@@ -96,7 +96,7 @@ func MakeCanvas(redisClient *redis.Client, canvasIdentifier string) error {
 }
 
 func SetPixel(pixelID int32, color int32, canvasIndentifier string, redisClient *redis.Client) error {
-	_, err := redisClient.Do(context.TODO(), "BITFIELD", canvasIndentifier, "SET", "i8", "#"+fmt.Sprint(pixelID), fmt.Sprint(color)).Result()
+	_, err := WritePixels(canvasIndentifier, []int32{pixelID}, []int32{color}, redisClient)
 	if err != nil {
 		return err
 	}
@@ -107,76 +107,128 @@ func SetPixel(pixelID int32, color int32, canvasIndentifier string, redisClient
 
 // #region Canvas
 func GetCanvas(canvasIdentifier string, redisClient *redis.Client) ([]int32, error) {
-	responseArr := make([]int32, models.DEFAULT_X_SIZE*models.DEFAULT_Y_SIZE)
+	width, height := CanvasSize(canvasIdentifier)
+	responseArr := make([]int32, width*height)
 	val, err := redisClient.Get(context.TODO(), canvasIdentifier).Result()
 	if err != nil {
 		return responseArr, err
 	}
-	for i, char := range val {
-		responseArr[i] = int32(char)
+	for i := 0; i < len(val) && i < len(responseArr); i++ {
+		responseArr[i] = int32(int8(val[i]))
 	}
 	return responseArr, nil
 
 }
 
-func CheckUserCooldown(userId string, redisClient *redis.Client) (bool, string) {
-	userCooldown, userCooldownError := redisClient.Get(context.TODO(), userId).Result()
-	if userCooldownError == redis.Nil {
-		return false, ""
+func UserCooldownKey(canvasIdentifier string, userId string) string {
+	return fmt.Sprintf("COOLDOWN:%s:USER:%s", canvasIdentifier, userId)
+}
+
+func PixelCooldownKey(canvasIdentifier string, pixelId int32) string {
+	return fmt.Sprintf("COOLDOWN:%s:PIXEL:%d", canvasIdentifier, pixelId)
+}
+
+// CooldownRemaining reads the time left on a cooldown key, 0 when it is not set
+func CooldownRemaining(key string, redisClient *redis.Client) (time.Duration, error) {
+	remaining, err := redisClient.PTTL(context.TODO(), key).Result()
+	if err != nil {
+		return 0, err
 	}
-	if userCooldown != "" {
-		secsLeft, err := time.Parse(time.RFC3339, userCooldown)
-		if err != nil {
-			return false, "Error calculating user cooldown expiry"
-		}
-		if userCooldown != "" {
-			return true, fmt.Sprintf("User Cooldown: Wait for %v before placing another pixel!", time.Until(secsLeft))
-		}
+	if remaining < 0 {
+		return 0, nil
+	}
+	return remaining, nil
+}
+
+func CheckUserCooldown(userId string, canvasIdentifier string, redisClient *redis.Client) (time.Duration, string) {
+	remaining, err := CooldownRemaining(UserCooldownKey(canvasIdentifier, userId), redisClient)
+	if err != nil {
+		return 0, "Error calculating user cooldown expiry"
+	}
+	if remaining > 0 {
+		return remaining, fmt.Sprintf("User Cooldown: Wait for %v before placing another pixel!", remaining.Round(time.Second))
+	}
+	return 0, ""
+
+}
+
+func CheckPixelCooldown(pixelId int32, canvasIdentifier string, redisClient *redis.Client) (time.Duration, string) {
+	remaining, err := CooldownRemaining(PixelCooldownKey(canvasIdentifier, pixelId), redisClient)
+	if err != nil {
+		return 0, "Error calculating pixel cooldown expiry"
 	}
-	return false, ""
+	if remaining > 0 {
+		return remaining, fmt.Sprintf("Pixel Cooldown: Wait for %v before placing another pixel!", remaining.Round(time.Second))
+	}
+	return 0, ""
 
 }
 
-func CheckPixelCooldown(pixelId int32, redisClient *redis.Client) (bool, string) {
-	pixelCooldown, pixelCooldownError := redisClient.Get(context.TODO(), fmt.Sprintf("PIXEL:%d", pixelId)).Result()
-	if pixelCooldownError == redis.Nil {
-		return false, ""
+// GetCooldown reports the time left on the user and pixel cooldowns in ms,
+// or the credit balance on canvases using credits
+func GetCooldown(userId string, canvasIdentifier string, pixelId int32, redisClient *redis.Client) (*canvas.ResponseMessage, error) {
+	response := &canvas.ResponseMessage{
+		MessageType: models.Success,
+		PixelId:     pixelId,
 	}
-	if pixelCooldown != "" {
-		secsLeft, err := time.Parse(time.RFC3339, pixelCooldown)
+	if UsesCredits(canvasIdentifier) {
+		credits, err := GetCredits(userId, redisClient)
 		if err != nil {
-			return false, "Error calculating pixel cooldown expiry"
+			return nil, err
 		}
-		if pixelCooldown != "" {
-			return true, fmt.Sprintf("Pixel Cooldown: Wait for %v before placing another pixel!", time.Until(secsLeft))
+		response.PixelsAvailable = int32(credits.Balance)
+		response.NextPixelAt = credits.NextRefillAt
+	} else {
+		userCooldown, err := CooldownRemaining(UserCooldownKey(canvasIdentifier, userId), redisClient)
+		if err != nil {
+			return nil, err
 		}
+		response.UserCooldownRemainingMs = userCooldown.Milliseconds()
+	}
+	pixelCooldown, err := CooldownRemaining(PixelCooldownKey(canvasIdentifier, pixelId), redisClient)
+	if err != nil {
+		return nil, err
 	}
-	return false, ""
+	response.PixelCooldownRemainingMs = pixelCooldown.Milliseconds()
+	return response, nil
+}
 
+// StartUserCooldown starts the cooldown for placing the given number of pixels
+func StartUserCooldown(userId string, canvasIdentifier string, pixels int, redisClient *redis.Client) error {
+	return redisClient.Set(context.TODO(), UserCooldownKey(canvasIdentifier, userId), 1, time.Duration(pixels)*models.USER_COOLDOWN_PERIOD*time.Second).Err()
 }
 
-func SetPixelAndPublish(pixelId int32, color int32, userId string, canvasIdentifier string, redisClient *redis.Client, mongoClient *mongo.Client) (bool, error) {
+func SetPixelAndPublish(pixelId int32, color int32, userId string, canvasIdentifier string, origin models.EventOrigin, redisClient *redis.Client, mongoClient *mongo.Client) (bool, error) {
+	write, err := BeginPixelWrite(canvasIdentifier, redisClient)
+	if err != nil {
+		return false, err
+	}
+	defer write.End()
 
-	err := SetPixel(pixelId, color, canvasIdentifier, redisClient)
+	_, err = write.WritePixels([]int32{pixelId}, []int32{color})
 	if err != nil {
 		return false, err
 	}
-	userCooldown := time.Now().Add(models.USER_COOLDOWN_PERIOD * time.Second).Format(time.RFC3339)
-	pixelCooldown := time.Now().Add(models.PIXEL_COOLDOWN_PERIOD * time.Second).Format(time.RFC3339)
 	message := &canvas.ResponseMessage{
-		MessageType: models.Update,
-		UserId:      userId,
-		PixelId:     pixelId,
-		Color:       color,
+		MessageType:      models.Update,
+		UserId:           userId,
+		PixelId:          pixelId,
+		Color:            color,
+		CanvasIdentifier: canvasIdentifier,
+		SessionId:        origin.SessionId,
+		RequestId:        origin.RequestId,
 	}
 	messageByte, err := proto.Marshal(message)
 	if err != nil {
 		return false, err
 	}
 	pipe := redisClient.Pipeline()
-	pipe.Do(context.TODO(), "SET", userId, userCooldown, "EX", models.USER_COOLDOWN_PERIOD).Result()
-	pipe.Do(context.TODO(), "SET", fmt.Sprintf("PIXEL:%d", pixelId), pixelCooldown, "EX", models.PIXEL_COOLDOWN_PERIOD).Result()
+	if !UsesCredits(canvasIdentifier) {
+		pipe.Set(context.TODO(), UserCooldownKey(canvasIdentifier, userId), 1, models.USER_COOLDOWN_PERIOD*time.Second)
+	}
+	pipe.Set(context.TODO(), PixelCooldownKey(canvasIdentifier, pixelId), 1, models.PIXEL_COOLDOWN_PERIOD*time.Second)
 	pipe.Publish(context.TODO(), "pixelUpdates", messageByte)
+	StreamUpdate(pipe, canvasIdentifier, messageByte)
 	_, err = pipe.Exec(context.TODO())
 	if err != nil {
 		return false, err
@@ -193,13 +245,29 @@ func SetPixelAndPublish(pixelId int32, color int32, userId string, canvasIdentif
 	filter := bson.M{"pixelId": pixelData.PixelId}
 	update := bson.M{"$set": pixelData}
 
-	updateOptions := options.Update().SetUpsert(true)
+	updateOptions := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.Before)
 
-	_, err = mongoClient.Database("canvas").Collection(canvasIdentifier).UpdateOne(context.TODO(), filter, update, updateOptions)
-	if err != nil {
+	var previousPixel models.PixelData
+	err = mongoClient.Database("canvas").Collection(canvasIdentifier).FindOneAndUpdate(context.TODO(), filter, update, updateOptions).Decode(&previousPixel)
+	if err != nil && err != mongo.ErrNoDocuments {
 		return false, err
 	}
 	//#endregion save to mongo
+
+	err = RecordLeaderboards(canvasIdentifier, userId, previousPixel.UserId, redisClient)
+	if err != nil {
+		log.Println("Error recording leaderboards:", err)
+	}
+	err = SaveUndo(userId, canvasIdentifier, []models.UndoPixel{{
+		PixelId:           pixelId,
+		Color:             color,
+		PreviousColor:     previousPixel.Color,
+		PreviousUserId:    previousPixel.UserId,
+		PreviousTimeStamp: previousPixel.TimeStamp,
+	}}, redisClient)
+	if err != nil {
+		log.Println("Error saving undo:", err)
+	}
 	return true, nil
 }
 
@@ -224,35 +292,3 @@ func CanvasExists(arr []string, element string) bool {
 }
 
 // #endregion Helper Functions
-
-// #region HW
-
-func GetPixelsAvailable(userId string) uint16 {
-
-	response, err := http.Get(fmt.Sprintf("https://example.com/getPixelsAvailable/%s", userId))
-	if err != nil {
-		log.Println("Error getting pixels available:", err)
-		return 0
-	}
-	body, err := io.ReadAll(response.Body)
-	defer response.Body.Close()
-	if err != nil {
-		log.Println("Error reading response body:", err)
-		return 0
-	}
-
-	var responseMap map[string]uint16
-	err = json.Unmarshal(body, &responseMap)
-	if err != nil {
-		log.Println("Error unmarshalling response body:", err)
-		return 0
-	}
-
-	if responseMap["pixelsAvailable"] > 0 {
-		return responseMap["pixelsAvailable"]
-	}
-
-	return 0
-}
-
-// #endregion HW
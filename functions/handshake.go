@@ -0,0 +1,57 @@
+package functions
+
+import (
+	"canvas/models"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/gorilla/websocket"
+)
+
+// #region Handshake
+
+// CheckOrigin allows requests without an Origin header (non-browser clients),
+// same-host origins when no allowlist is configured and otherwise only allowlisted origins.
+func CheckOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	originUrl, err := url.Parse(origin)
+	if err != nil {
+		return false
+	}
+	if len(models.ALLOWED_ORIGINS) == 0 {
+		return strings.EqualFold(originUrl.Host, r.Host)
+	}
+	for _, allowed := range models.ALLOWED_ORIGINS {
+		if allowed == "*" || strings.EqualFold(allowed, origin) {
+			return true
+		}
+		// "https://*.example.com" matches every subdomain of example.com over https
+		scheme, host, found := strings.Cut(allowed, "://*.")
+		if found && strings.EqualFold(scheme, originUrl.Scheme) && strings.HasSuffix(strings.ToLower(originUrl.Host), "."+strings.ToLower(host)) {
+			return true
+		}
+	}
+	return false
+}
+
+// HasUnknownSubprotocol reports whether the client asked for subprotocols and none of them are supported.
+func HasUnknownSubprotocol(r *http.Request) bool {
+	requested := websocket.Subprotocols(r)
+	if len(requested) == 0 {
+		return false
+	}
+	for _, protocol := range requested {
+		for _, supported := range models.SUBPROTOCOLS {
+			if protocol == supported {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// #endregion Handshake
@@ -0,0 +1,103 @@
+package functions
+
+import (
+	"canvas/models"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// #region Leaderboard
+
+// leaderboardKey names the sorted set of a window. Daily boards are per UTC day and
+// event boards per lifecycle phase, so both start over on their own.
+func leaderboardKey(canvasIdentifier string, window string) string {
+	switch window {
+	case models.LEADERBOARD_DAILY:
+		return fmt.Sprintf("LEADERBOARD:%s:%s:%s", canvasIdentifier, window, time.Now().UTC().Format("20060102"))
+	case models.LEADERBOARD_EVENT:
+		return fmt.Sprintf("LEADERBOARD:%s:%s:%d", canvasIdentifier, window, CanvasLifecycle(canvasIdentifier).StartsAt)
+	}
+	return fmt.Sprintf("LEADERBOARD:%s:%s", canvasIdentifier, window)
+}
+
+func IsLeaderboardWindow(window string) bool {
+	for _, w := range models.LEADERBOARD_WINDOWS {
+		if w == window {
+			return true
+		}
+	}
+	return false
+}
+
+// RecordLeaderboards counts a placement for the user and moves the pixel still showing from its previous owner
+func RecordLeaderboards(canvasIdentifier string, userId string, previousUserId string, redisClient *redis.Client) error {
+	dailyKey := leaderboardKey(canvasIdentifier, models.LEADERBOARD_DAILY)
+	showingKey := leaderboardKey(canvasIdentifier, models.LEADERBOARD_SHOWING)
+	pipe := redisClient.Pipeline()
+	pipe.ZIncrBy(context.TODO(), leaderboardKey(canvasIdentifier, models.LEADERBOARD_ALL), 1, userId)
+	pipe.ZIncrBy(context.TODO(), dailyKey, 1, userId)
+	pipe.Expire(context.TODO(), dailyKey, models.LEADERBOARD_DAILY_DAYS*24*time.Hour)
+	pipe.ZIncrBy(context.TODO(), leaderboardKey(canvasIdentifier, models.LEADERBOARD_EVENT), 1, userId)
+	pipe.ZIncrBy(context.TODO(), showingKey, 1, userId)
+	if previousUserId != "" {
+		pipe.ZIncrBy(context.TODO(), showingKey, -1, previousUserId)
+		pipe.ZRemRangeByScore(context.TODO(), showingKey, "-inf", "0")
+	}
+	_, err := pipe.Exec(context.TODO())
+	return err
+}
+
+// UndoLeaderboards takes back a placement counted by RecordLeaderboards
+func UndoLeaderboards(canvasIdentifier string, userId string, previousUserId string, redisClient *redis.Client) error {
+	showingKey := leaderboardKey(canvasIdentifier, models.LEADERBOARD_SHOWING)
+	pipe := redisClient.Pipeline()
+	pipe.ZIncrBy(context.TODO(), leaderboardKey(canvasIdentifier, models.LEADERBOARD_ALL), -1, userId)
+	pipe.ZIncrBy(context.TODO(), leaderboardKey(canvasIdentifier, models.LEADERBOARD_DAILY), -1, userId)
+	pipe.ZIncrBy(context.TODO(), leaderboardKey(canvasIdentifier, models.LEADERBOARD_EVENT), -1, userId)
+	pipe.ZIncrBy(context.TODO(), showingKey, -1, userId)
+	if previousUserId != "" {
+		pipe.ZIncrBy(context.TODO(), showingKey, 1, previousUserId)
+	}
+	pipe.ZRemRangeByScore(context.TODO(), showingKey, "-inf", "0")
+	_, err := pipe.Exec(context.TODO())
+	return err
+}
+
+// GetLeaderboard returns a page of a leaderboard window, highest score first
+func GetLeaderboard(canvasIdentifier string, window string, offset int64, limit int64, redisClient *redis.Client) (models.Leaderboard, error) {
+	leaderboard := models.Leaderboard{Window: window, Offset: offset, Entries: []models.LeaderboardEntry{}}
+	if !IsLeaderboardWindow(window) {
+		return leaderboard, fmt.Errorf("unknown leaderboard window %q", window)
+	}
+	if offset < 0 {
+		return leaderboard, fmt.Errorf("invalid offset %v", offset)
+	}
+	if limit <= 0 {
+		limit = models.LEADERBOARD_DEFAULT_LIMIT
+	}
+	if limit > models.LEADERBOARD_MAX_LIMIT {
+		limit = models.LEADERBOARD_MAX_LIMIT
+	}
+	key := leaderboardKey(canvasIdentifier, window)
+	pipe := redisClient.Pipeline()
+	total := pipe.ZCard(context.TODO(), key)
+	page := pipe.ZRevRangeWithScores(context.TODO(), key, offset, offset+limit-1)
+	_, err := pipe.Exec(context.TODO())
+	if err != nil {
+		return leaderboard, err
+	}
+	leaderboard.Total = total.Val()
+	for i, entry := range page.Val() {
+		leaderboard.Entries = append(leaderboard.Entries, models.LeaderboardEntry{
+			Rank:   offset + int64(i) + 1,
+			UserId: entry.Member.(string),
+			Score:  int64(entry.Score),
+		})
+	}
+	return leaderboard, nil
+}
+
+// #endregion Leaderboard
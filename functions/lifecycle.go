@@ -0,0 +1,121 @@
+package functions
+
+import (
+	"canvas/catalogue"
+	"canvas/models"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// #region Lifecycle
+
+// lifecycles caches the current phase of every canvas, see RefreshLifecycles
+var lifecycles sync.Map
+
+func lifecycleKey(canvasIdentifier string) string {
+	return "LIFECYCLE:" + canvasIdentifier
+}
+
+func GetSchedule(canvasIdentifier string, redisClient *redis.Client) ([]models.LifecyclePhase, error) {
+	var schedule []models.LifecyclePhase
+	val, err := redisClient.Get(context.TODO(), lifecycleKey(canvasIdentifier)).Result()
+	if err == redis.Nil {
+		return schedule, nil
+	}
+	if err != nil {
+		return schedule, err
+	}
+	err = json.Unmarshal([]byte(val), &schedule)
+	return schedule, err
+}
+
+// SetSchedule replaces the schedule of a canvas, an empty schedule keeps the canvas open
+func SetSchedule(canvasIdentifier string, schedule []models.LifecyclePhase, redisClient *redis.Client) error {
+	sort.Slice(schedule, func(i, j int) bool {
+		return schedule[i].StartsAt < schedule[j].StartsAt
+	})
+	for i, phase := range schedule {
+		if !isCanvasState(phase.State) {
+			return fmt.Errorf("unknown canvas state %q", phase.State)
+		}
+		if phase.EndsAt != 0 && phase.EndsAt <= phase.StartsAt {
+			return fmt.Errorf("%v phase ends before it starts", phase.State)
+		}
+		if i > 0 && (schedule[i-1].EndsAt == 0 || schedule[i-1].EndsAt > phase.StartsAt) {
+			return errors.New("phases overlap")
+		}
+	}
+	if len(schedule) == 0 {
+		return redisClient.Del(context.TODO(), lifecycleKey(canvasIdentifier)).Err()
+	}
+	scheduleByte, err := json.Marshal(schedule)
+	if err != nil {
+		return err
+	}
+	return redisClient.Set(context.TODO(), lifecycleKey(canvasIdentifier), scheduleByte, 0).Err()
+}
+
+func isCanvasState(state string) bool {
+	for _, s := range models.CANVAS_STATES {
+		if s == state {
+			return true
+		}
+	}
+	return false
+}
+
+// CurrentPhase finds the phase of the schedule at the given time. Canvases without a schedule are open,
+// before the first phase they are scheduled and in gaps between phases or after the last one they are closed.
+func CurrentPhase(schedule []models.LifecyclePhase, now int64) models.LifecyclePhase {
+	if len(schedule) == 0 {
+		return models.LifecyclePhase{State: models.CANVAS_OPEN}
+	}
+	current := models.LifecyclePhase{State: models.CANVAS_SCHEDULED}
+	for _, phase := range schedule {
+		if now < phase.StartsAt {
+			current.EndsAt = phase.StartsAt
+			return current
+		}
+		if phase.EndsAt == 0 || now < phase.EndsAt {
+			return phase
+		}
+		current = models.LifecyclePhase{State: models.CANVAS_CLOSED, StartsAt: phase.EndsAt}
+	}
+	return current
+}
+
+// RefreshLifecycles reloads every canvas schedule and returns the canvases whose phase changed
+func RefreshLifecycles(redisClient *redis.Client) ([]string, error) {
+	var changed []string
+	now := time.Now().Unix()
+	for _, canvasIdentifier := range catalogue.CANVAS_LIST {
+		schedule, err := GetSchedule(canvasIdentifier, redisClient)
+		if err != nil {
+			return changed, err
+		}
+		phase := CurrentPhase(schedule, now)
+		previous, found := lifecycles.Swap(canvasIdentifier, phase)
+		if found && previous.(models.LifecyclePhase) != phase {
+			changed = append(changed, canvasIdentifier)
+		}
+	}
+	return changed, nil
+}
+
+// CanvasLifecycle returns the cached phase of a canvas
+func CanvasLifecycle(canvasIdentifier string) models.LifecyclePhase {
+	phase, found := lifecycles.Load(canvasIdentifier)
+	if !found {
+		return models.LifecyclePhase{State: models.CANVAS_OPEN}
+	}
+	return phase.(models.LifecyclePhase)
+}
+
+// #endregion Lifecycle
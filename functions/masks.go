@@ -0,0 +1,56 @@
+package functions
+
+import (
+	"canvas/catalogue"
+	"canvas/models"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// #region Masks
+
+// LoadMasks loads the mask of every canvas, first from models.MASK_DIR and then from the masks collection in mongo
+func LoadMasks(mongoClient *mongo.Client) error {
+	masks := map[string]*models.Mask{}
+	for _, canvasIdentifier := range catalogue.CANVAS_LIST {
+		maskName := catalogue.CANVAS_CONFIGS[canvasIdentifier].Mask
+		if maskName == "" || masks[maskName] != nil {
+			continue
+		}
+		mask, err := loadMask(maskName, mongoClient)
+		if err != nil {
+			return fmt.Errorf("mask %v of %v: %w", maskName, canvasIdentifier, err)
+		}
+		masks[maskName] = mask
+	}
+	catalogue.MASKS = masks
+	return nil
+}
+
+func loadMask(maskName string, mongoClient *mongo.Client) (*models.Mask, error) {
+	for _, ext := range []string{catalogue.MASK_EXT_RLE, catalogue.MASK_EXT_PNG} {
+		mask, err := catalogue.LoadMaskFile(filepath.Join(models.MASK_DIR, maskName+ext))
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		return mask, err
+	}
+	var maskDocument models.MaskDocument
+	err := mongoClient.Database("canvas").Collection("masks").FindOne(context.TODO(), bson.M{"name": maskName}).Decode(&maskDocument)
+	if err == mongo.ErrNoDocuments {
+		return nil, errors.New("not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return catalogue.DecodeMaskRLE(strings.NewReader(fmt.Sprintf("%d %d %s", maskDocument.Width, maskDocument.Height, maskDocument.RLE)))
+}
+
+// #endregion Masks
@@ -0,0 +1,61 @@
+package functions
+
+import (
+	"canvas/catalogue"
+	"canvas/models"
+	canvas "canvas/proto"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// #region Palette
+
+// PaletteMessage lists the palette of a canvas for GET_CONFIG
+func PaletteMessage(canvasIdentifier string) []*canvas.PaletteColor {
+	palette := catalogue.CanvasPalette(canvasIdentifier)
+	paletteColors := make([]*canvas.PaletteColor, len(palette))
+	for i, paletteColor := range palette {
+		paletteColors[i] = &canvas.PaletteColor{
+			Color: int32(i + 1),
+			Hex:   paletteColor.Hex,
+			Name:  paletteColor.Name,
+		}
+	}
+	return paletteColors
+}
+
+// WriteCanvasPNG renders the canvas with its palette, empty pixels and unknown colours are drawn as EMPTY_PIXEL_HEX
+func WriteCanvasPNG(canvasIdentifier string, w io.Writer, redisClient *redis.Client) error {
+	pixels, err := GetCanvas(canvasIdentifier, redisClient)
+	if err != nil && err != redis.Nil {
+		return err
+	}
+	empty, err := catalogue.ParseHexColor(models.EMPTY_PIXEL_HEX)
+	if err != nil {
+		return err
+	}
+	palette := color.Palette{empty}
+	for _, paletteColor := range catalogue.CanvasPalette(canvasIdentifier) {
+		rgba, err := catalogue.ParseHexColor(paletteColor.Hex)
+		if err != nil {
+			return err
+		}
+		palette = append(palette, rgba)
+	}
+
+	width, height := CanvasSize(canvasIdentifier)
+	img := image.NewPaletted(image.Rect(0, 0, int(width), int(height)), palette)
+	for i, pixel := range pixels {
+		if pixel < 0 || int(pixel) >= len(palette) {
+			continue
+		}
+		img.Pix[i] = uint8(pixel)
+	}
+	return png.Encode(w, img)
+}
+
+// #endregion Palette
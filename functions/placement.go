@@ -0,0 +1,521 @@
+package functions
+
+import (
+	"canvas/models"
+	canvas "canvas/proto"
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"google.golang.org/protobuf/proto"
+)
+
+// #region Batch Placement
+
+// BrushPixels expands a SET_PIXELS request into the pixels it paints, without duplicates
+func BrushPixels(request *canvas.RequestMessage, canvasIdentifier string) ([]int32, error) {
+	width, height := CanvasSize(canvasIdentifier)
+	inCanvas := func(pixelId int32) bool {
+		return pixelId >= 0 && pixelId < width*height
+	}
+
+	var pixelIds []int32
+	switch request.GetBrush() {
+	case models.BRUSH_LIST:
+		pixelIds = request.GetPixelIds()
+	case models.BRUSH_LINE:
+		if !inCanvas(request.GetPixelId()) || !inCanvas(request.GetToPixelId()) {
+			return nil, fmt.Errorf("line is outside of the canvas")
+		}
+		pixelIds = linePixels(request.GetPixelId(), request.GetToPixelId(), width)
+	case models.BRUSH_SQUARE:
+		size := request.GetBrushSize()
+		if size < 1 || int(size*size) > models.MAX_BATCH_PIXELS {
+			return nil, fmt.Errorf("brush size must be between 1 and %v pixels", models.MAX_BATCH_PIXELS)
+		}
+		x, y := request.GetPixelId()%width, request.GetPixelId()/width
+		if !inCanvas(request.GetPixelId()) || x+size > width || y+size > height {
+			return nil, fmt.Errorf("square is outside of the canvas")
+		}
+		for dy := int32(0); dy < size; dy++ {
+			for dx := int32(0); dx < size; dx++ {
+				pixelIds = append(pixelIds, (y+dy)*width+x+dx)
+			}
+		}
+	default:
+		return nil, fmt.Errorf("unknown brush %v", request.GetBrush())
+	}
+
+	seen := map[int32]bool{}
+	brushed := []int32{}
+	for _, pixelId := range pixelIds {
+		if seen[pixelId] {
+			continue
+		}
+		seen[pixelId] = true
+		brushed = append(brushed, pixelId)
+		if len(brushed) > models.MAX_BATCH_PIXELS {
+			return nil, fmt.Errorf("a batch can place at most %v pixels", models.MAX_BATCH_PIXELS)
+		}
+	}
+	if len(brushed) == 0 {
+		return nil, fmt.Errorf("no pixels to place")
+	}
+	return brushed, nil
+}
+
+// linePixels walks from one pixel to another with Bresenham's algorithm
+func linePixels(from int32, to int32, width int32) []int32 {
+	x, y := from%width, from/width
+	toX, toY := to%width, to/width
+	dx, dy := abs(toX-x), -abs(toY-y)
+	stepX, stepY := int32(1), int32(1)
+	if x > toX {
+		stepX = -1
+	}
+	if y > toY {
+		stepY = -1
+	}
+	pixelIds := []int32{}
+	err := dx + dy
+	for {
+		pixelIds = append(pixelIds, y*width+x)
+		// stop early, the batch limit is checked by the caller
+		if (x == toX && y == toY) || len(pixelIds) > models.MAX_BATCH_PIXELS {
+			return pixelIds
+		}
+		doubled := 2 * err
+		if doubled >= dy {
+			err += dy
+			x += stepX
+		}
+		if doubled <= dx {
+			err += dx
+			y += stepY
+		}
+	}
+}
+
+func abs(n int32) int32 {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// SetPixelsAndPublish places a batch of pixels of one colour and publishes them as a single UpdateBatch.
+// The canvas, the pixel history and the cooldowns are all updated or, when a step fails, all put back.
+// The user cooldown of canvases without credits grows with the number of pixels.
+func SetPixelsAndPublish(pixelIds []int32, color int32, userId string, canvasIdentifier string, origin models.EventOrigin, redisClient *redis.Client, mongoClient *mongo.Client) (bool, error) {
+	write, err := BeginPixelWrite(canvasIdentifier, redisClient)
+	if err != nil {
+		return false, err
+	}
+	defer write.End()
+	collection := mongoClient.Database("canvas").Collection(canvasIdentifier)
+
+	//#region read history
+	cursor, err := collection.Find(context.TODO(), bson.M{"pixelId": bson.M{"$in": pixelIds}})
+	if err != nil {
+		return false, err
+	}
+	var previousPixels []models.PixelData
+	err = cursor.All(context.TODO(), &previousPixels)
+	if err != nil {
+		return false, err
+	}
+	//#endregion read history
+
+	//#region set pixels
+	colors := make([]int32, len(pixelIds))
+	for i := range colors {
+		colors[i] = color
+	}
+	previousColors, err := write.WritePixels(pixelIds, colors)
+	if err != nil {
+		return false, err
+	}
+	//#endregion set pixels
+
+	//#region save to mongo
+	timeStamp := time.Now().Unix()
+	writes := make([]mongo.WriteModel, len(pixelIds))
+	for i, pixelId := range pixelIds {
+		pixelData := models.PixelData{
+			UserId:    userId,
+			PixelId:   pixelId,
+			Color:     color,
+			TimeStamp: timeStamp,
+		}
+		writes[i] = mongo.NewUpdateOneModel().SetFilter(bson.M{"pixelId": pixelId}).SetUpdate(bson.M{"$set": pixelData}).SetUpsert(true)
+	}
+	_, err = collection.BulkWrite(context.TODO(), writes)
+	if err != nil {
+		rollbackPixels(write, pixelIds, previousColors, previousPixels, canvasIdentifier, redisClient, mongoClient)
+		return false, err
+	}
+	//#endregion save to mongo
+
+	//#region publish
+	pixels := make([]*canvas.Pixel, len(pixelIds))
+	for i, pixelId := range pixelIds {
+		pixels[i] = &canvas.Pixel{PixelId: pixelId, Color: color}
+	}
+	message := &canvas.ResponseMessage{
+		MessageType:      models.UpdateBatch,
+		UserId:           userId,
+		Color:            color,
+		Pixels:           pixels,
+		CanvasIdentifier: canvasIdentifier,
+		SessionId:        origin.SessionId,
+		RequestId:        origin.RequestId,
+	}
+	messageByte, err := proto.Marshal(message)
+	if err != nil {
+		rollbackPixels(write, pixelIds, previousColors, previousPixels, canvasIdentifier, redisClient, mongoClient)
+		return false, err
+	}
+	pipe := redisClient.TxPipeline()
+	if !UsesCredits(canvasIdentifier) {
+		pipe.Set(context.TODO(), UserCooldownKey(canvasIdentifier, userId), 1, time.Duration(len(pixelIds))*models.USER_COOLDOWN_PERIOD*time.Second)
+	}
+	for _, pixelId := range pixelIds {
+		pipe.Set(context.TODO(), PixelCooldownKey(canvasIdentifier, pixelId), 1, models.PIXEL_COOLDOWN_PERIOD*time.Second)
+	}
+	pipe.Publish(context.TODO(), "pixelUpdates", messageByte)
+	StreamUpdate(pipe, canvasIdentifier, messageByte)
+	_, err = pipe.Exec(context.TODO())
+	if err != nil {
+		rollbackPixels(write, pixelIds, previousColors, previousPixels, canvasIdentifier, redisClient, mongoClient)
+		return false, err
+	}
+	//#endregion publish
+
+	previous := map[int32]models.PixelData{}
+	for _, previousPixel := range previousPixels {
+		previous[previousPixel.PixelId] = previousPixel
+	}
+	undoPixels := make([]models.UndoPixel, len(pixelIds))
+	for i, pixelId := range pixelIds {
+		err = RecordLeaderboards(canvasIdentifier, userId, previous[pixelId].UserId, redisClient)
+		if err != nil {
+			log.Println("Error recording leaderboards:", err)
+		}
+		undoPixels[i] = models.UndoPixel{
+			PixelId:           pixelId,
+			Color:             color,
+			PreviousColor:     int32(previousColors[i]),
+			PreviousUserId:    previous[pixelId].UserId,
+			PreviousTimeStamp: previous[pixelId].TimeStamp,
+		}
+	}
+	err = SaveUndo(userId, canvasIdentifier, undoPixels, redisClient)
+	if err != nil {
+		log.Println("Error saving undo:", err)
+	}
+	return true, nil
+}
+
+// rollbackPixels puts back the colours and history a failed batch overwrote, before its PixelWrite ends
+func rollbackPixels(write *PixelWrite, pixelIds []int32, previousColors []int64, previousPixels []models.PixelData, canvasIdentifier string, redisClient *redis.Client, mongoClient *mongo.Client) {
+	colors := make([]int32, len(pixelIds))
+	for i := range colors {
+		colors[i] = int32(previousColors[i])
+	}
+	_, err := write.WritePixels(pixelIds, colors)
+	if err != nil {
+		log.Println("Error rolling back pixels:", err)
+	}
+
+	previous := map[int32]models.PixelData{}
+	for _, previousPixel := range previousPixels {
+		previous[previousPixel.PixelId] = previousPixel
+	}
+	writes := make([]mongo.WriteModel, len(pixelIds))
+	for i, pixelId := range pixelIds {
+		if previousPixel, found := previous[pixelId]; found {
+			writes[i] = mongo.NewReplaceOneModel().SetFilter(bson.M{"pixelId": pixelId}).SetReplacement(previousPixel).SetUpsert(true)
+		} else {
+			writes[i] = mongo.NewDeleteOneModel().SetFilter(bson.M{"pixelId": pixelId})
+		}
+	}
+	_, err = mongoClient.Database("canvas").Collection(canvasIdentifier).BulkWrite(context.TODO(), writes, options.BulkWrite().SetOrdered(false))
+	if err != nil {
+		log.Println("Error rolling back pixel history:", err)
+	}
+}
+
+// PlacementResult is what a placement answers, for the caller to pass on to the placer
+type PlacementResult struct {
+	// Success or the reason the placement was refused
+	Response *canvas.ResponseMessage
+	// Balance after spending credits
+	Credits *models.CreditBalance
+	// Update only the placer sees when shadow banned
+	Shadow *canvas.ResponseMessage
+}
+
+// PlacePixels runs the checks of a SET_CANVAS or SET_PIXELS request and places it when they all pass.
+// Every transport places pixels through here so they share validation, cooldowns and credits.
+func PlacePixels(request *canvas.RequestMessage, placer models.Placer, redisClient *redis.Client, mongoClient *mongo.Client) PlacementResult {
+	var result PlacementResult
+
+	//#region verify placeTileMessage
+	pixelIds := []int32{request.GetPixelId()}
+	if request.GetMessageType() == models.SET_PIXELS {
+		var err error
+		pixelIds, err = BrushPixels(request, placer.CanvasIdentifier)
+		if err != nil {
+			result.Response = &canvas.ResponseMessage{
+				MessageType: models.Error,
+				Reason:      canvas.Reason_INVALID_BRUSH,
+				Message:     fmt.Sprintf("Not a valid brush: %v!", err),
+			}
+			return result
+		}
+	}
+	isValid, invalidPixelId := true, int32(0)
+	for _, pixelId := range pixelIds {
+		if !VerifyPlaceTileMessage(pixelId, request.GetColor(), placer.CanvasIdentifier) {
+			isValid, invalidPixelId = false, pixelId
+			break
+		}
+	}
+	if !isValid {
+		result.Response = &canvas.ResponseMessage{
+			MessageType: models.Error,
+			Reason:      canvas.Reason_INVALID_PIXEL,
+			Message:     "Not a valid place tile request!",
+			PixelId:     invalidPixelId,
+			Color:       request.GetColor(),
+		}
+		return result
+	}
+	//#endregion verify placeTileMessage
+
+	//#region check lifecycle
+	phase := CanvasLifecycle(placer.CanvasIdentifier)
+	if phase.State != models.CANVAS_OPEN {
+		result.Response = &canvas.ResponseMessage{
+			MessageType:   models.CanvasNotOpen,
+			Reason:        canvas.Reason_CANVAS_NOT_OPEN,
+			Message:       fmt.Sprintf("Canvas is %v!", phase.State),
+			CanvasState:   phase.State,
+			StateStartsAt: phase.StartsAt,
+			StateEndsAt:   phase.EndsAt,
+		}
+		return result
+	}
+	resizing, err := IsResizing(placer.CanvasIdentifier, redisClient)
+	if err != nil {
+		log.Println("ERR49: ", err)
+	}
+	if resizing {
+		result.Response = &canvas.ResponseMessage{
+			MessageType: models.CanvasNotOpen,
+			Reason:      canvas.Reason_CANVAS_RESIZING,
+			Message:     "Canvas is being resized!",
+			CanvasState: phase.State,
+		}
+		return result
+	}
+	//#endregion check lifecycle
+
+	//#region check sanctions
+	sanctions, err := GetSanctions(placer.UserId, redisClient)
+	if err != nil {
+		log.Println("ERR21: ", err)
+		result.Response = &canvas.ResponseMessage{
+			MessageType: models.Error,
+			Reason:      canvas.Reason_INTERNAL_ERROR,
+			Message:     "Error checking sanctions!",
+		}
+		return result
+	}
+	if sanctions.Ban != nil || sanctions.Mute != nil {
+		response := &canvas.ResponseMessage{
+			MessageType: models.Error,
+		}
+		if sanctions.Ban != nil {
+			response.Reason = canvas.Reason_BANNED
+			response.Message = fmt.Sprintf("Banned: %v", sanctions.Ban.Reason)
+		} else {
+			retryAfter := time.Until(time.Unix(sanctions.Mute.ExpiresAt, 0))
+			response.Reason = canvas.Reason_MUTED
+			response.Message = fmt.Sprintf("Muted: %v. Wait for %v before placing another pixel!", sanctions.Mute.Reason, retryAfter.Round(time.Second))
+			response.RetryAfterMs = retryAfter.Milliseconds()
+		}
+		result.Response = response
+		return result
+	}
+	//#endregion check sanctions
+
+	//#region check team region
+	team, err := GetTeam(placer.UserId, placer.TeamClaim, redisClient)
+	if err != nil {
+		log.Println("ERR53: ", err)
+	}
+	for _, pixelId := range pixelIds {
+		if !CanTeamPaint(placer.CanvasIdentifier, pixelId, team) {
+			result.Response = &canvas.ResponseMessage{
+				MessageType: models.Error,
+				Reason:      canvas.Reason_TEAM_REGION,
+				Message:     "This region belongs to another team!",
+				PixelId:     pixelId,
+			}
+			return result
+		}
+	}
+	//#endregion check team region
+
+	//#region canSet pixel
+	// canvases using credits replace the user cooldown with the credit balance
+	useCredits := UsesCredits(placer.CanvasIdentifier)
+	userCoolDown, message := time.Duration(0), ""
+	if !useCredits {
+		userCoolDown, message = CheckUserCooldown(placer.UserId, placer.CanvasIdentifier, redisClient)
+	}
+	if userCoolDown > 0 {
+		result.Response = &canvas.ResponseMessage{
+			MessageType:             models.UserCooldown,
+			Reason:                  canvas.Reason_USER_COOLDOWN,
+			Message:                 message,
+			UserCooldownRemainingMs: userCoolDown.Milliseconds(),
+			RetryAfterMs:            userCoolDown.Milliseconds(),
+		}
+		return result
+	}
+	for _, pixelId := range pixelIds {
+		pixelCoolDown, message := CheckPixelCooldown(pixelId, placer.CanvasIdentifier, redisClient)
+		if pixelCoolDown > 0 {
+			result.Response = &canvas.ResponseMessage{
+				MessageType:              models.PixelCooldown,
+				Reason:                   canvas.Reason_PIXEL_COOLDOWN,
+				Message:                  message,
+				PixelId:                  pixelId,
+				PixelCooldownRemainingMs: pixelCoolDown.Milliseconds(),
+				RetryAfterMs:             pixelCoolDown.Milliseconds(),
+			}
+			return result
+		}
+	}
+	//#endregion canSet pixel
+
+	//#region Spend credit
+	if useCredits {
+		credits, err := SpendCredits(placer.UserId, len(pixelIds), redisClient)
+		if err != nil {
+			log.Println("ERR37: ", err)
+			result.Response = &canvas.ResponseMessage{
+				MessageType: models.Error,
+				Reason:      canvas.Reason_INTERNAL_ERROR,
+				Message:     "Error spending pixel credit!",
+			}
+			return result
+		}
+		if !credits.Spent {
+			result.Response = &canvas.ResponseMessage{
+				MessageType:     models.UserCooldown,
+				Reason:          canvas.Reason_NOT_ENOUGH_CREDITS,
+				Message:         fmt.Sprintf("Not enough pixels left: %v of %v available, the next one comes in %v!", credits.Balance, len(pixelIds), time.Until(time.UnixMilli(credits.NextRefillAt)).Round(time.Second)),
+				RetryAfterMs:    time.Until(time.UnixMilli(credits.NextRefillAt)).Milliseconds(),
+				PixelsAvailable: int32(credits.Balance),
+				NextPixelAt:     credits.NextRefillAt,
+			}
+			return result
+		}
+		result.Credits = &credits
+	}
+	//#endregion Spend credit
+
+	//#region Shadow pixel
+	// shadow-banned placements are echoed to the placer only and never stored or published
+	if sanctions.Shadow != nil {
+		if !useCredits {
+			err := StartUserCooldown(placer.UserId, placer.CanvasIdentifier, len(pixelIds), redisClient)
+			if err != nil {
+				log.Println("ERR24: ", err)
+			}
+		}
+		update := &canvas.ResponseMessage{
+			MessageType:      models.Update,
+			UserId:           placer.UserId,
+			PixelId:          request.GetPixelId(),
+			Color:            request.GetColor(),
+			CanvasIdentifier: placer.CanvasIdentifier,
+		}
+		if request.GetMessageType() == models.SET_PIXELS {
+			update = &canvas.ResponseMessage{
+				MessageType:      models.UpdateBatch,
+				UserId:           placer.UserId,
+				Color:            request.GetColor(),
+				CanvasIdentifier: placer.CanvasIdentifier,
+			}
+			for _, pixelId := range pixelIds {
+				update.Pixels = append(update.Pixels, &canvas.Pixel{PixelId: pixelId, Color: request.GetColor()})
+			}
+		}
+		result.Shadow = update
+	}
+	//#endregion Shadow pixel
+
+	//#region Set pixel
+	if sanctions.Shadow == nil {
+		var success bool
+		if request.GetMessageType() == models.SET_PIXELS {
+			success, err = SetPixelsAndPublish(pixelIds, request.GetColor(), placer.UserId, placer.CanvasIdentifier, placer.Origin, redisClient, mongoClient)
+		} else {
+			success, err = SetPixelAndPublish(request.GetPixelId(), request.GetColor(), placer.UserId, placer.CanvasIdentifier, placer.Origin, redisClient, mongoClient)
+		}
+		if !success {
+			if useCredits {
+				err := RefundCredits(placer.UserId, len(pixelIds), redisClient)
+				if err != nil {
+					log.Println("ERR40: ", err)
+				}
+			}
+			if err == ErrCanvasResizing {
+				// a resize started after the lifecycle check
+				result.Response = &canvas.ResponseMessage{
+					MessageType: models.CanvasNotOpen,
+					Reason:      canvas.Reason_CANVAS_RESIZING,
+					Message:     "Canvas is being resized!",
+					CanvasState: phase.State,
+				}
+				return result
+			}
+			log.Println("ERR10: ", err)
+			result.Response = &canvas.ResponseMessage{
+				MessageType: models.Error,
+				Reason:      canvas.Reason_INTERNAL_ERROR,
+				Message:     "Error setting pixel!",
+			}
+			return result
+		}
+		err = RecordTeamPlacement(placer.CanvasIdentifier, team, len(pixelIds), redisClient)
+		if err != nil {
+			log.Println("ERR55: ", err)
+		}
+	}
+	//#endregion Set pixel
+
+	//#region Send Response
+	response := &canvas.ResponseMessage{
+		MessageType:      models.Success,
+		Message:          "Pixel set!",
+		CanvasIdentifier: placer.CanvasIdentifier,
+	}
+	if request.GetMessageType() == models.SET_PIXELS {
+		response.Message = fmt.Sprintf("%v pixels set!", len(pixelIds))
+	}
+	result.Response = response
+	//#endregion Send Response
+	return result
+}
+
+// #endregion Batch Placement
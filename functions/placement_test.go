@@ -0,0 +1,119 @@
+package functions
+
+import (
+	"canvas/catalogue"
+	"canvas/models"
+	canvas "canvas/proto"
+	"reflect"
+	"testing"
+)
+
+const brushTestCanvas = "BRUSH_TEST_CANVAS"
+
+func TestLinePixels(t *testing.T) {
+	tests := []struct {
+		name     string
+		from, to int32
+		want     []int32
+	}{
+		{name: "single pixel", from: 11, to: 11, want: []int32{11}},
+		{name: "horizontal", from: 0, to: 3, want: []int32{0, 1, 2, 3}},
+		{name: "horizontal backwards", from: 3, to: 0, want: []int32{3, 2, 1, 0}},
+		{name: "vertical", from: 2, to: 32, want: []int32{2, 12, 22, 32}},
+		{name: "diagonal", from: 0, to: 33, want: []int32{0, 11, 22, 33}},
+		{name: "anti-diagonal", from: 9, to: 36, want: []int32{9, 18, 27, 36}},
+		{name: "shallow slope", from: 0, to: 14, want: []int32{0, 1, 12, 13, 14}},
+		{name: "steep slope", from: 0, to: 41, want: []int32{0, 10, 21, 31, 41}},
+		{name: "along the right edge", from: 9, to: 99, want: []int32{9, 19, 29, 39, 49, 59, 69, 79, 89, 99}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := linePixels(test.from, test.to, 10)
+			if !reflect.DeepEqual(got, test.want) {
+				t.Fatalf("got %v, want %v", got, test.want)
+			}
+			if got[0] != test.from || got[len(got)-1] != test.to {
+				t.Fatalf("line %v does not run from %v to %v", got, test.from, test.to)
+			}
+		})
+	}
+}
+
+func TestBrushPixels(t *testing.T) {
+	SetCanvasSize(brushTestCanvas, 10, 10)
+	maxBatchPixels := models.MAX_BATCH_PIXELS
+	models.MAX_BATCH_PIXELS = 16
+	defer func() { models.MAX_BATCH_PIXELS = maxBatchPixels }()
+
+	tests := []struct {
+		name    string
+		request *canvas.RequestMessage
+		want    []int32
+		wantErr bool
+	}{
+		{name: "list", request: &canvas.RequestMessage{Brush: models.BRUSH_LIST, PixelIds: []int32{5, 1, 99}}, want: []int32{5, 1, 99}},
+		{name: "list without duplicates", request: &canvas.RequestMessage{Brush: models.BRUSH_LIST, PixelIds: []int32{5, 1, 5, 1}}, want: []int32{5, 1}},
+		{name: "empty list", request: &canvas.RequestMessage{Brush: models.BRUSH_LIST}, wantErr: true},
+		{name: "list over the batch limit", request: &canvas.RequestMessage{Brush: models.BRUSH_LIST, PixelIds: []int32{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}}, wantErr: true},
+		{name: "list at the batch limit with duplicates", request: &canvas.RequestMessage{Brush: models.BRUSH_LIST, PixelIds: []int32{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 15}}, want: []int32{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15}},
+		{name: "line", request: &canvas.RequestMessage{Brush: models.BRUSH_LINE, PixelId: 90, ToPixelId: 93}, want: []int32{90, 91, 92, 93}},
+		{name: "line to the last pixel", request: &canvas.RequestMessage{Brush: models.BRUSH_LINE, PixelId: 97, ToPixelId: 99}, want: []int32{97, 98, 99}},
+		{name: "line starting outside", request: &canvas.RequestMessage{Brush: models.BRUSH_LINE, PixelId: -1, ToPixelId: 3}, wantErr: true},
+		{name: "line ending outside", request: &canvas.RequestMessage{Brush: models.BRUSH_LINE, PixelId: 0, ToPixelId: 100}, wantErr: true},
+		{name: "line over the batch limit", request: &canvas.RequestMessage{Brush: models.BRUSH_LINE, PixelId: 0, ToPixelId: 99}, want: []int32{0, 11, 22, 33, 44, 55, 66, 77, 88, 99}},
+		{name: "square", request: &canvas.RequestMessage{Brush: models.BRUSH_SQUARE, PixelId: 11, BrushSize: 2}, want: []int32{11, 12, 21, 22}},
+		{name: "square in the bottom right corner", request: &canvas.RequestMessage{Brush: models.BRUSH_SQUARE, PixelId: 88, BrushSize: 2}, want: []int32{88, 89, 98, 99}},
+		{name: "square over the right border", request: &canvas.RequestMessage{Brush: models.BRUSH_SQUARE, PixelId: 9, BrushSize: 2}, wantErr: true},
+		{name: "square over the bottom border", request: &canvas.RequestMessage{Brush: models.BRUSH_SQUARE, PixelId: 90, BrushSize: 2}, wantErr: true},
+		{name: "square of size 0", request: &canvas.RequestMessage{Brush: models.BRUSH_SQUARE, PixelId: 0, BrushSize: 0}, wantErr: true},
+		{name: "square over the batch limit", request: &canvas.RequestMessage{Brush: models.BRUSH_SQUARE, PixelId: 0, BrushSize: 5}, wantErr: true},
+		{name: "unknown brush", request: &canvas.RequestMessage{Brush: 42, PixelId: 0}, wantErr: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := BrushPixels(test.request, brushTestCanvas)
+			if test.wantErr {
+				if err == nil {
+					t.Fatalf("got %v, want an error", got)
+				}
+				return
+			}
+			if err != nil || !reflect.DeepEqual(got, test.want) {
+				t.Fatalf("got %v, %v, want %v", got, err, test.want)
+			}
+		})
+	}
+}
+
+func TestVerifyPlaceTileMessageMask(t *testing.T) {
+	SetCanvasSize(brushTestCanvas, 10, 10)
+	mask := models.NewMask(10, 5)
+	mask.Cells[0], mask.Cells[49] = true, true
+	catalogue.MASKS[brushTestCanvas] = mask
+	catalogue.CANVAS_CONFIGS[brushTestCanvas] = models.CanvasConfig{Identifier: brushTestCanvas, Mask: brushTestCanvas}
+	defer delete(catalogue.MASKS, brushTestCanvas)
+	defer delete(catalogue.CANVAS_CONFIGS, brushTestCanvas)
+
+	tests := []struct {
+		name    string
+		pixelId int32
+		color   int32
+		want    bool
+	}{
+		{name: "placeable cell", pixelId: 0, color: 1, want: true},
+		{name: "last placeable cell", pixelId: 49, color: 1, want: true},
+		{name: "masked cell", pixelId: 1, color: 1},
+		{name: "cell below the mask", pixelId: 50, color: 1},
+		{name: "outside of the canvas", pixelId: 100, color: 1},
+		{name: "negative pixel", pixelId: -1, color: 1},
+		{name: "empty colour", pixelId: 0, color: 0},
+		{name: "colour past the palette", pixelId: 0, color: int32(len(catalogue.CanvasPalette(brushTestCanvas)) + 1)},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := VerifyPlaceTileMessage(test.pixelId, test.color, brushTestCanvas); got != test.want {
+				t.Fatalf("got %v, want %v", got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,285 @@
+package functions
+
+import (
+	"canvas/models"
+	canvas "canvas/proto"
+	"fmt"
+	"strconv"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// #region Protocol
+
+// NegotiateProtocol takes the protocol of the negotiated subprotocol, or else the version and format
+// query parameters, defaulting to v1 protobuf
+func NegotiateProtocol(subprotocol string, queryVersion string, queryFormat string) (models.Protocol, error) {
+	if protocol, found := models.SUBPROTOCOL_PROTOCOLS[subprotocol]; found {
+		return protocol, nil
+	}
+	protocol := models.Protocol{Version: models.PROTOCOL_V1, Format: models.FORMAT_PROTOBUF}
+	if queryVersion != "" {
+		version, err := strconv.Atoi(queryVersion)
+		if err != nil || (version != models.PROTOCOL_V1 && version != models.PROTOCOL_V2) {
+			return protocol, fmt.Errorf("unsupported protocol version %q", queryVersion)
+		}
+		protocol.Version = int32(version)
+	}
+	if queryFormat != "" {
+		if queryFormat != models.FORMAT_PROTOBUF && queryFormat != models.FORMAT_JSON {
+			return protocol, fmt.Errorf("unsupported wire format %q", queryFormat)
+		}
+		protocol.Format = queryFormat
+	}
+	return protocol, nil
+}
+
+func unmarshal(data []byte, message proto.Message, format string) error {
+	if format == models.FORMAT_JSON {
+		return protojson.Unmarshal(data, message)
+	}
+	return proto.Unmarshal(data, message)
+}
+
+func marshal(message proto.Message, format string) ([]byte, error) {
+	if format == models.FORMAT_JSON {
+		return protojson.Marshal(message)
+	}
+	return proto.Marshal(message)
+}
+
+// DecodeRequest reads a request in the protocol of the client. v2 envelopes are unwrapped into the flat
+// RequestMessage the handlers work with.
+func DecodeRequest(data []byte, protocol models.Protocol) (*canvas.RequestMessage, error) {
+	if protocol.Version != models.PROTOCOL_V2 {
+		var request canvas.RequestMessage
+		err := unmarshal(data, &request, protocol.Format)
+		return &request, err
+	}
+
+	var envelope canvas.ClientEnvelope
+	err := unmarshal(data, &envelope, protocol.Format)
+	if err != nil {
+		return nil, err
+	}
+	request, err := unwrapRequest(&envelope)
+	if err != nil {
+		return nil, err
+	}
+	request.RequestId = envelope.GetRequestId()
+	return request, nil
+}
+
+func unwrapRequest(envelope *canvas.ClientEnvelope) (*canvas.RequestMessage, error) {
+	switch payload := envelope.GetPayload().(type) {
+	case *canvas.ClientEnvelope_GetConfig:
+		return &canvas.RequestMessage{MessageType: models.GET_CONFIG, CanvasIdentifier: payload.GetConfig.GetCanvasIdentifier()}, nil
+	case *canvas.ClientEnvelope_GetCanvas:
+		return &canvas.RequestMessage{MessageType: models.GET_CANVAS, CanvasIdentifier: payload.GetCanvas.GetCanvasIdentifier()}, nil
+	case *canvas.ClientEnvelope_SetPixel:
+		return &canvas.RequestMessage{
+			MessageType: models.SET_CANVAS,
+			PixelId:     payload.SetPixel.GetPixelId(),
+			Color:       payload.SetPixel.GetColor(),
+		}, nil
+	case *canvas.ClientEnvelope_ViewPixel:
+		return &canvas.RequestMessage{MessageType: models.VIEW_PIXEL, PixelId: payload.ViewPixel.GetPixelId()}, nil
+	case *canvas.ClientEnvelope_GetCooldown:
+		return &canvas.RequestMessage{MessageType: models.GET_COOLDOWN, PixelId: payload.GetCooldown.GetPixelId()}, nil
+	case *canvas.ClientEnvelope_GetLeaderboard:
+		return &canvas.RequestMessage{
+			MessageType:       models.GET_LEADERBOARD,
+			LeaderboardWindow: payload.GetLeaderboard.GetWindow(),
+			Offset:            payload.GetLeaderboard.GetOffset(),
+			Limit:             payload.GetLeaderboard.GetLimit(),
+		}, nil
+	case *canvas.ClientEnvelope_SetPixels:
+		return &canvas.RequestMessage{
+			MessageType: models.SET_PIXELS,
+			Brush:       payload.SetPixels.GetBrush(),
+			PixelIds:    payload.SetPixels.GetPixelIds(),
+			PixelId:     payload.SetPixels.GetPixelId(),
+			ToPixelId:   payload.SetPixels.GetToPixelId(),
+			BrushSize:   payload.SetPixels.GetBrushSize(),
+			Color:       payload.SetPixels.GetColor(),
+		}, nil
+	case *canvas.ClientEnvelope_UndoPlacement:
+		return &canvas.RequestMessage{MessageType: models.UNDO_PLACEMENT}, nil
+	case *canvas.ClientEnvelope_JoinCanvas:
+		return &canvas.RequestMessage{
+			MessageType:      models.JOIN_CANVAS,
+			CanvasIdentifier: payload.JoinCanvas.GetCanvasIdentifier(),
+			Watch:            payload.JoinCanvas.GetWatch(),
+		}, nil
+	}
+	return nil, fmt.Errorf("envelope without a known payload")
+}
+
+// EncodeResponse writes a response in the protocol of the client. request is the request being answered,
+// nil for events pushed by the server, and decides which payload a v2 Success carries.
+func EncodeResponse(response *canvas.ResponseMessage, request *canvas.RequestMessage, protocol models.Protocol) ([]byte, error) {
+	if protocol.Version != models.PROTOCOL_V2 {
+		return marshal(response, protocol.Format)
+	}
+	return marshal(ToEnvelope(response, request), protocol.Format)
+}
+
+// ToEnvelope converts a flat response into its v2 envelope
+func ToEnvelope(response *canvas.ResponseMessage, request *canvas.RequestMessage) *canvas.ServerEnvelope {
+	phase := &canvas.CanvasPhase{
+		State:    response.GetCanvasState(),
+		StartsAt: response.GetStateStartsAt(),
+		EndsAt:   response.GetStateEndsAt(),
+	}
+	if phase.State == "" {
+		phase = nil
+	}
+	envelope := &canvas.ServerEnvelope{RequestId: response.GetRequestId()}
+	switch response.GetMessageType() {
+	case models.Update:
+		envelope.Payload = &canvas.ServerEnvelope_Update{Update: &canvas.PixelUpdate{
+			UserId:           response.GetUserId(),
+			PixelId:          response.GetPixelId(),
+			Color:            response.GetColor(),
+			CanvasIdentifier: response.GetCanvasIdentifier(),
+		}}
+	case models.UpdateBatch:
+		envelope.Payload = &canvas.ServerEnvelope_UpdateBatch{UpdateBatch: &canvas.PixelBatch{
+			UserId:           response.GetUserId(),
+			Pixels:           response.GetPixels(),
+			CanvasIdentifier: response.GetCanvasIdentifier(),
+		}}
+	case models.Credits:
+		envelope.Payload = &canvas.ServerEnvelope_Credits{Credits: &canvas.CreditBalance{
+			PixelsAvailable: response.GetPixelsAvailable(),
+			NextPixelAt:     response.GetNextPixelAt(),
+		}}
+	case models.CanvasState:
+		phase.CanvasIdentifier = response.GetCanvasIdentifier()
+		envelope.Payload = &canvas.ServerEnvelope_CanvasState{CanvasState: phase}
+	case models.CanvasResized:
+		envelope.Payload = &canvas.ServerEnvelope_CanvasResized{CanvasResized: &canvas.CanvasResize{
+			CanvasIdentifier: response.GetCanvasIdentifier(),
+			CanvasWidth:      response.GetCanvasWidth(),
+			CanvasHeight:     response.GetCanvasHeight(),
+		}}
+	case models.Success:
+		setSuccessPayload(envelope, response, request, phase)
+	default:
+		envelope.Payload = &canvas.ServerEnvelope_Rejection{Rejection: &canvas.Rejection{
+			Reason:                   response.GetReason(),
+			Message:                  response.GetMessage(),
+			PixelId:                  response.GetPixelId(),
+			Color:                    response.GetColor(),
+			RetryAfterMs:             response.GetRetryAfterMs(),
+			UserCooldownRemainingMs:  response.GetUserCooldownRemainingMs(),
+			PixelCooldownRemainingMs: response.GetPixelCooldownRemainingMs(),
+			PixelsAvailable:          response.GetPixelsAvailable(),
+			NextPixelAt:              response.GetNextPixelAt(),
+			Phase:                    phase,
+		}}
+	}
+	return envelope
+}
+
+// setSuccessPayload picks the payload of a Success by the request it answers
+func setSuccessPayload(envelope *canvas.ServerEnvelope, response *canvas.ResponseMessage, request *canvas.RequestMessage, phase *canvas.CanvasPhase) {
+	switch request.GetMessageType() {
+	case models.GET_CONFIG, models.JOIN_CANVAS:
+		envelope.Payload = &canvas.ServerEnvelope_Config{Config: &canvas.Config{
+			CanvasWidth:       response.GetCanvasWidth(),
+			CanvasHeight:      response.GetCanvasHeight(),
+			UserCooldown:      response.GetUserCooldown(),
+			PixelCooldown:     response.GetPixelCooldown(),
+			PingInterval:      response.GetPingInterval(),
+			DisconnectTimeout: response.GetDisconnectTimeout(),
+			Phase:             phase,
+			PixelsAvailable:   response.GetPixelsAvailable(),
+			NextPixelAt:       response.GetNextPixelAt(),
+			Palette:           response.GetPalette(),
+			CanvasIdentifier:  response.GetCanvasIdentifier(),
+		}}
+	case models.GET_CANVAS:
+		envelope.Payload = &canvas.ServerEnvelope_Canvas{Canvas: &canvas.CanvasSnapshot{
+			Canvas:           response.GetCanvas(),
+			CanvasIdentifier: response.GetCanvasIdentifier(),
+		}}
+	case models.VIEW_PIXEL:
+		envelope.Payload = &canvas.ServerEnvelope_Pixel{Pixel: &canvas.PixelInfo{
+			PixelId: response.GetPixelId(),
+			Color:   response.GetColor(),
+			UserId:  response.GetUserId(),
+		}}
+	case models.GET_COOLDOWN:
+		envelope.Payload = &canvas.ServerEnvelope_Cooldown{Cooldown: &canvas.Cooldown{
+			PixelId:                  response.GetPixelId(),
+			UserCooldownRemainingMs:  response.GetUserCooldownRemainingMs(),
+			PixelCooldownRemainingMs: response.GetPixelCooldownRemainingMs(),
+			PixelsAvailable:          response.GetPixelsAvailable(),
+			NextPixelAt:              response.GetNextPixelAt(),
+		}}
+	case models.GET_LEADERBOARD:
+		envelope.Payload = &canvas.ServerEnvelope_Leaderboard{Leaderboard: &canvas.LeaderboardPage{
+			Entries: response.GetLeaderboard(),
+			Total:   response.GetLeaderboardTotal(),
+		}}
+	default:
+		envelope.Payload = &canvas.ServerEnvelope_Placed{Placed: &canvas.Placed{
+			Message:          response.GetMessage(),
+			Pixels:           response.GetPixels(),
+			CanvasIdentifier: response.GetCanvasIdentifier(),
+		}}
+	}
+}
+
+// EventEncoder encodes a published event once per protocol for all the clients receiving it.
+// Only the session the event originates from gets the RequestId, the SessionId is never sent.
+type EventEncoder struct {
+	event   *canvas.ResponseMessage
+	origin  models.EventOrigin
+	encoded map[models.Protocol][]byte
+}
+
+func NewEventEncoder(payload []byte) (*EventEncoder, error) {
+	var event canvas.ResponseMessage
+	err := proto.Unmarshal(payload, &event)
+	if err != nil {
+		return nil, err
+	}
+	encoder := &EventEncoder{
+		event:   &event,
+		origin:  models.EventOrigin{SessionId: event.GetSessionId(), RequestId: event.GetRequestId()},
+		encoded: map[models.Protocol][]byte{},
+	}
+	if encoder.origin == (models.EventOrigin{}) {
+		encoder.encoded[models.Protocol{Version: models.PROTOCOL_V1, Format: models.FORMAT_PROTOBUF}] = payload
+	}
+	event.SessionId = ""
+	event.RequestId = ""
+	return encoder, nil
+}
+
+func (e *EventEncoder) Event() *canvas.ResponseMessage {
+	return e.event
+}
+
+func (e *EventEncoder) Encode(protocol models.Protocol, sessionId string) ([]byte, error) {
+	// REST and gRPC placements have no session, the stream viewers without one must not get their RequestId
+	if e.origin.RequestId != "" && e.origin.SessionId != "" && e.origin.SessionId == sessionId {
+		reply := proto.Clone(e.event).(*canvas.ResponseMessage)
+		reply.RequestId = e.origin.RequestId
+		return EncodeResponse(reply, nil, protocol)
+	}
+	if encoded, found := e.encoded[protocol]; found {
+		return encoded, nil
+	}
+	encoded, err := EncodeResponse(e.event, nil, protocol)
+	if err != nil {
+		return nil, err
+	}
+	e.encoded[protocol] = encoded
+	return encoded, nil
+}
+
+// #endregion Protocol
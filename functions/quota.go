@@ -0,0 +1,253 @@
+package functions
+
+import (
+	"canvas/models"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// #region Quota
+
+// QuotaProvider decides how many pixels a user can hold at once
+type QuotaProvider interface {
+	PixelsAvailable(ctx context.Context, userId string) (uint16, error)
+}
+
+// Quota is the provider configured through models.QUOTA_PROVIDER, nil when quotas come from models.CREDIT_CAP
+var Quota = NewQuotaProvider()
+
+func NewQuotaProvider() QuotaProvider {
+	var provider QuotaProvider
+	switch models.QUOTA_PROVIDER {
+	case models.QUOTA_PROVIDER_HTTP:
+		provider = &HTTPQuotaProvider{
+			URL:          models.QUOTA_URL,
+			Field:        models.QUOTA_FIELD,
+			Retries:      models.QUOTA_RETRIES,
+			RetryBackoff: models.QUOTA_RETRY_BACKOFF,
+			Client:       &http.Client{Timeout: models.QUOTA_TIMEOUT},
+		}
+	case models.QUOTA_PROVIDER_STUB:
+		provider = &StubQuotaProvider{Default: uint16(models.CREDIT_CAP)}
+	default:
+		return nil
+	}
+	return &CachedQuotaProvider{
+		Provider:       provider,
+		TTL:            models.QUOTA_CACHE_TTL,
+		LastKnownTTL:   models.QUOTA_LAST_KNOWN_TTL,
+		FailureMode:    models.QUOTA_FAILURE_MODE,
+		FailOpenPixels: uint16(models.QUOTA_FAIL_OPEN_PIXELS),
+	}
+}
+
+// GetPixelsAvailable returns the user's quota from the configured provider, or models.CREDIT_CAP without one.
+// It fails when the provider fails and its failure mode has no quota to fall back on.
+func GetPixelsAvailable(userId string) (uint16, error) {
+	if Quota == nil {
+		return uint16(models.CREDIT_CAP), nil
+	}
+	return Quota.PixelsAvailable(context.TODO(), userId)
+}
+
+// #region HTTP Provider
+
+// HTTPQuotaProvider asks an external service for the quota. "{userId}" in URL is replaced with the user id
+// and Field is the dot separated path of the number in the JSON response, e.g. "data.pixelsAvailable".
+type HTTPQuotaProvider struct {
+	URL          string
+	Field        string
+	Retries      int
+	RetryBackoff time.Duration
+	Client       *http.Client
+}
+
+func (p *HTTPQuotaProvider) PixelsAvailable(ctx context.Context, userId string) (uint16, error) {
+	var err error
+	for attempt := 0; attempt <= p.Retries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-time.After(p.RetryBackoff << (attempt - 1)):
+			case <-ctx.Done():
+				return 0, ctx.Err()
+			}
+		}
+		var pixelsAvailable uint16
+		var retry bool
+		pixelsAvailable, retry, err = p.fetch(ctx, userId)
+		if err == nil {
+			return pixelsAvailable, nil
+		}
+		if !retry {
+			break
+		}
+	}
+	return 0, err
+}
+
+// fetch makes a single request, the returned bool tells whether the error is worth retrying
+func (p *HTTPQuotaProvider) fetch(ctx context.Context, userId string) (uint16, bool, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.ReplaceAll(p.URL, "{userId}", userId), nil)
+	if err != nil {
+		return 0, false, err
+	}
+	response, err := p.Client.Do(request)
+	if err != nil {
+		return 0, true, err
+	}
+	defer response.Body.Close()
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return 0, true, err
+	}
+	if response.StatusCode >= 500 {
+		return 0, true, fmt.Errorf("quota provider returned %v", response.Status)
+	}
+	if response.StatusCode != http.StatusOK {
+		return 0, false, fmt.Errorf("quota provider returned %v", response.Status)
+	}
+
+	var responseMap interface{}
+	err = json.Unmarshal(body, &responseMap)
+	if err != nil {
+		return 0, false, err
+	}
+	for _, key := range strings.Split(p.Field, ".") {
+		object, ok := responseMap.(map[string]interface{})
+		if !ok {
+			return 0, false, fmt.Errorf("quota field %q not found", p.Field)
+		}
+		responseMap = object[key]
+	}
+	pixelsAvailable, ok := responseMap.(float64)
+	if !ok || pixelsAvailable < 0 {
+		return 0, false, fmt.Errorf("quota field %q is not a valid number", p.Field)
+	}
+	if pixelsAvailable > float64(^uint16(0)) {
+		pixelsAvailable = float64(^uint16(0))
+	}
+	return uint16(pixelsAvailable), false, nil
+}
+
+// #endregion HTTP Provider
+
+// #region Stub Provider
+
+// StubQuotaProvider serves quotas from memory, for tests and local development
+type StubQuotaProvider struct {
+	mu      sync.RWMutex
+	Pixels  map[string]uint16
+	Default uint16
+	Err     error
+}
+
+func (p *StubQuotaProvider) PixelsAvailable(ctx context.Context, userId string) (uint16, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if p.Err != nil {
+		return 0, p.Err
+	}
+	if pixelsAvailable, ok := p.Pixels[userId]; ok {
+		return pixelsAvailable, nil
+	}
+	return p.Default, nil
+}
+
+func (p *StubQuotaProvider) Set(userId string, pixelsAvailable uint16) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.Pixels == nil {
+		p.Pixels = map[string]uint16{}
+	}
+	p.Pixels[userId] = pixelsAvailable
+}
+
+func (p *StubQuotaProvider) SetError(err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.Err = err
+}
+
+// #endregion Stub Provider
+
+// #region Cached Provider
+
+type cachedQuota struct {
+	pixelsAvailable uint16
+	fetchedAt       time.Time
+}
+
+// CachedQuotaProvider keeps provider results per user for TTL and applies FailureMode when the provider fails.
+// Results older than LastKnownTTL, or TTL when it is longer, are no longer used as the last known quota
+// and are swept from the cache.
+type CachedQuotaProvider struct {
+	Provider       QuotaProvider
+	TTL            time.Duration
+	LastKnownTTL   time.Duration
+	FailureMode    string
+	FailOpenPixels uint16
+	cache          sync.Map
+	mu             sync.Mutex
+	sweptAt        time.Time
+}
+
+// maxAge is how long a result is kept in the cache
+func (p *CachedQuotaProvider) maxAge() time.Duration {
+	if p.LastKnownTTL > p.TTL {
+		return p.LastKnownTTL
+	}
+	return p.TTL
+}
+
+// sweep drops the expired results, at most once per TTL
+func (p *CachedQuotaProvider) sweep() {
+	p.mu.Lock()
+	if time.Since(p.sweptAt) < p.TTL {
+		p.mu.Unlock()
+		return
+	}
+	p.sweptAt = time.Now()
+	p.mu.Unlock()
+	p.cache.Range(func(key, value interface{}) bool {
+		if time.Since(value.(cachedQuota).fetchedAt) >= p.maxAge() {
+			p.cache.Delete(key)
+		}
+		return true
+	})
+}
+
+func (p *CachedQuotaProvider) PixelsAvailable(ctx context.Context, userId string) (uint16, error) {
+	p.sweep()
+	cached, found := p.cache.Load(userId)
+	found = found && time.Since(cached.(cachedQuota).fetchedAt) < p.maxAge()
+	if found && time.Since(cached.(cachedQuota).fetchedAt) < p.TTL {
+		return cached.(cachedQuota).pixelsAvailable, nil
+	}
+	pixelsAvailable, err := p.Provider.PixelsAvailable(ctx, userId)
+	if err == nil {
+		p.cache.Store(userId, cachedQuota{pixelsAvailable: pixelsAvailable, fetchedAt: time.Now()})
+		return pixelsAvailable, nil
+	}
+	switch p.FailureMode {
+	case models.QUOTA_FAIL_OPEN:
+		log.Println("Error getting pixels available, failing open:", err)
+		return p.FailOpenPixels, nil
+	case models.QUOTA_LAST_KNOWN:
+		if found {
+			log.Println("Error getting pixels available, using the last known quota:", err)
+			return cached.(cachedQuota).pixelsAvailable, nil
+		}
+	}
+	return 0, err
+}
+
+// #endregion Cached Provider
+
+// #endregion Quota
@@ -0,0 +1,154 @@
+package functions
+
+import (
+	"canvas/models"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCachedQuotaProviderFailureModes(t *testing.T) {
+	outage := errors.New("quota service down")
+	tests := []struct {
+		name        string
+		failureMode string
+		cached      bool
+		want        uint16
+		wantErr     bool
+	}{
+		{name: "fail closed", failureMode: models.QUOTA_FAIL_CLOSED, cached: true, wantErr: true},
+		{name: "fail open", failureMode: models.QUOTA_FAIL_OPEN, want: 3},
+		{name: "fail open ignores the cache", failureMode: models.QUOTA_FAIL_OPEN, cached: true, want: 3},
+		{name: "last known", failureMode: models.QUOTA_LAST_KNOWN, cached: true, want: 25},
+		{name: "last known without a cached quota", failureMode: models.QUOTA_LAST_KNOWN, wantErr: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			stub := &StubQuotaProvider{Default: 10}
+			stub.Set("user", 25)
+			provider := &CachedQuotaProvider{
+				Provider:       stub,
+				TTL:            time.Nanosecond,
+				LastKnownTTL:   time.Hour,
+				FailureMode:    test.failureMode,
+				FailOpenPixels: 3,
+			}
+			if test.cached {
+				if _, err := provider.PixelsAvailable(context.Background(), "user"); err != nil {
+					t.Fatalf("filling the cache: %v", err)
+				}
+			}
+			stub.SetError(outage)
+			time.Sleep(time.Millisecond)
+
+			got, err := provider.PixelsAvailable(context.Background(), "user")
+			if test.wantErr {
+				if !errors.Is(err, outage) {
+					t.Fatalf("got %v, %v, want the provider error", got, err)
+				}
+				return
+			}
+			if err != nil || got != test.want {
+				t.Fatalf("got %v, %v, want %v", got, err, test.want)
+			}
+		})
+	}
+}
+
+func TestCachedQuotaProviderServesCacheWithinTTL(t *testing.T) {
+	stub := &StubQuotaProvider{Default: 10}
+	provider := &CachedQuotaProvider{Provider: stub, TTL: time.Hour, FailureMode: models.QUOTA_FAIL_CLOSED}
+	if _, err := provider.PixelsAvailable(context.Background(), "user"); err != nil {
+		t.Fatal(err)
+	}
+	stub.Set("user", 20)
+	got, err := provider.PixelsAvailable(context.Background(), "user")
+	if err != nil || got != 10 {
+		t.Fatalf("got %v, %v, want the cached 10", got, err)
+	}
+}
+
+func TestCachedQuotaProviderSweepsExpiredResults(t *testing.T) {
+	provider := &CachedQuotaProvider{
+		Provider:     &StubQuotaProvider{Default: 10},
+		TTL:          time.Millisecond,
+		LastKnownTTL: time.Millisecond,
+		FailureMode:  models.QUOTA_LAST_KNOWN,
+	}
+	for i := 0; i < 100; i++ {
+		if _, err := provider.PixelsAvailable(context.Background(), fmt.Sprint("user", i)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	time.Sleep(5 * time.Millisecond)
+	if _, err := provider.PixelsAvailable(context.Background(), "last"); err != nil {
+		t.Fatal(err)
+	}
+	entries := 0
+	provider.cache.Range(func(key, value interface{}) bool {
+		entries++
+		return true
+	})
+	if entries != 1 {
+		t.Fatalf("got %v cached results after the sweep, want 1", entries)
+	}
+}
+
+func TestHTTPQuotaProvider(t *testing.T) {
+	tests := []struct {
+		name      string
+		field     string
+		responses []int
+		body      string
+		want      uint16
+		wantErr   bool
+		wantCalls int32
+	}{
+		{name: "top level field", field: "pixelsAvailable", responses: []int{200}, body: `{"pixelsAvailable": 12}`, want: 12, wantCalls: 1},
+		{name: "nested field", field: "data.quota.pixels", responses: []int{200}, body: `{"data": {"quota": {"pixels": 7}}}`, want: 7, wantCalls: 1},
+		{name: "missing field", field: "data.pixels", responses: []int{200}, body: `{"data": 7}`, wantErr: true, wantCalls: 1},
+		{name: "negative quota", field: "pixelsAvailable", responses: []int{200}, body: `{"pixelsAvailable": -1}`, wantErr: true, wantCalls: 1},
+		{name: "quota above uint16 is capped", field: "pixelsAvailable", responses: []int{200}, body: `{"pixelsAvailable": 100000}`, want: 65535, wantCalls: 1},
+		{name: "server errors are retried", field: "pixelsAvailable", responses: []int{503, 500, 200}, body: `{"pixelsAvailable": 4}`, want: 4, wantCalls: 3},
+		{name: "retries run out", field: "pixelsAvailable", responses: []int{503, 503, 503}, wantErr: true, wantCalls: 3},
+		{name: "client errors are not retried", field: "pixelsAvailable", responses: []int{404}, wantErr: true, wantCalls: 1},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var calls int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				call := atomic.AddInt32(&calls, 1)
+				if r.URL.Path != "/quota/user" {
+					t.Errorf("requested %v, want /quota/user", r.URL.Path)
+				}
+				status := test.responses[len(test.responses)-1]
+				if int(call) <= len(test.responses) {
+					status = test.responses[call-1]
+				}
+				w.WriteHeader(status)
+				fmt.Fprint(w, test.body)
+			}))
+			defer server.Close()
+			provider := &HTTPQuotaProvider{
+				URL:          server.URL + "/quota/{userId}",
+				Field:        test.field,
+				Retries:      2,
+				RetryBackoff: time.Millisecond,
+				Client:       server.Client(),
+			}
+
+			got, err := provider.PixelsAvailable(context.Background(), "user")
+			if test.wantErr != (err != nil) || got != test.want {
+				t.Fatalf("got %v, %v, want %v (error: %v)", got, err, test.want, test.wantErr)
+			}
+			if calls != test.wantCalls {
+				t.Fatalf("made %v requests, want %v", calls, test.wantCalls)
+			}
+		})
+	}
+}
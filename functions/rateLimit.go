@@ -0,0 +1,113 @@
+package functions
+
+import (
+	"canvas/models"
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// #region Rate Limit
+
+type Bucket struct {
+	Key   string
+	Limit models.RateLimit
+}
+
+// takeTokensScript refills every bucket in KEYS and takes ARGV[1] tokens from each of them.
+// Tokens are only taken when every bucket has enough, otherwise the longest wait in ms is returned.
+var takeTokensScript = redis.NewScript(`
+local time = redis.call('TIME')
+local now = tonumber(time[1]) * 1000 + math.floor(tonumber(time[2]) / 1000)
+local cost = tonumber(ARGV[1])
+local tokens = {}
+local wait = 0
+for i = 1, #KEYS do
+	local capacity = tonumber(ARGV[i * 2])
+	local rate = tonumber(ARGV[i * 2 + 1])
+	local state = redis.call('HMGET', KEYS[i], 'tokens', 'ts')
+	local current = tonumber(state[1]) or capacity
+	local ts = tonumber(state[2]) or now
+	current = math.min(capacity, current + (now - ts) * rate)
+	tokens[i] = current
+	if current < cost then
+		wait = math.max(wait, math.ceil((cost - current) / rate))
+	end
+end
+if wait > 0 then
+	return {0, wait}
+end
+for i = 1, #KEYS do
+	local capacity = tonumber(ARGV[i * 2])
+	local rate = tonumber(ARGV[i * 2 + 1])
+	redis.call('HSET', KEYS[i], 'tokens', tostring(tokens[i] - cost), 'ts', now)
+	redis.call('PEXPIRE', KEYS[i], math.ceil(capacity / rate) + 1000)
+end
+return {1, 0}
+`)
+
+// TakeTokens takes cost tokens from every bucket or from none of them.
+// When the request is refused the returned duration tells when it can be retried.
+func TakeTokens(buckets []Bucket, cost int, redisClient *redis.Client) (bool, time.Duration, error) {
+	if len(buckets) == 0 {
+		return true, 0, nil
+	}
+	keys := make([]string, 0, len(buckets))
+	args := make([]interface{}, 0, len(buckets)*2+1)
+	args = append(args, cost)
+	for _, bucket := range buckets {
+		keys = append(keys, bucket.Key)
+		args = append(args, bucket.Limit.Capacity, strconv.FormatFloat(bucket.Limit.RefillPerSec/1000, 'f', -1, 64))
+	}
+	result, err := takeTokensScript.Run(context.TODO(), redisClient, keys, args...).Int64Slice()
+	if err != nil {
+		return false, 0, err
+	}
+	return result[0] == 1, time.Duration(result[1]) * time.Millisecond, nil
+}
+
+// UpgradeBuckets and MessageBuckets leave out the IP bucket for an empty ip and the user bucket for an
+// empty userId, so the user bucket can be taken separately once the user is authenticated
+func UpgradeBuckets(ip string, userId string) []Bucket {
+	var buckets []Bucket
+	if ip != "" {
+		buckets = append(buckets, Bucket{Key: "RATELIMIT:IP:" + ip + ":UPGRADE", Limit: models.IP_UPGRADE_LIMIT})
+	}
+	if userId != "" {
+		buckets = append(buckets, Bucket{Key: "RATELIMIT:USER:" + userId + ":UPGRADE", Limit: models.USER_UPGRADE_LIMIT})
+	}
+	return buckets
+}
+
+func MessageBuckets(ip string, userId string, messageType int32) []Bucket {
+	var buckets []Bucket
+	if limit, ok := models.IP_MESSAGE_LIMITS[messageType]; ok && ip != "" {
+		buckets = append(buckets, Bucket{Key: fmt.Sprintf("RATELIMIT:IP:%s:%d", ip, messageType), Limit: limit})
+	}
+	if limit, ok := models.USER_MESSAGE_LIMITS[messageType]; ok && userId != "" {
+		buckets = append(buckets, Bucket{Key: fmt.Sprintf("RATELIMIT:USER:%s:%d", userId, messageType), Limit: limit})
+	}
+	return buckets
+}
+
+func ClientIP(r *http.Request) string {
+	if models.TRUST_PROXY_HEADERS {
+		forwardedFor := r.Header.Get("X-Forwarded-For")
+		if forwardedFor != "" {
+			return strings.TrimSpace(strings.Split(forwardedFor, ",")[0])
+		}
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// #endregion Rate Limit
@@ -0,0 +1,119 @@
+package functions
+
+import (
+	"canvas/models"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/redis/go-redis/v9"
+)
+
+// #region Sanctions
+
+func sanctionKey(sanctionType string, userId string) string {
+	return fmt.Sprintf("SANCTION:%s:%s", sanctionType, userId)
+}
+
+func isSanctionType(sanctionType string) bool {
+	for _, t := range models.SANCTION_TYPES {
+		if t == sanctionType {
+			return true
+		}
+	}
+	return false
+}
+
+func SetSanction(sanction models.Sanction, redisClient *redis.Client) error {
+	if !isSanctionType(sanction.Type) {
+		return fmt.Errorf("unknown sanction type %q", sanction.Type)
+	}
+	if sanction.Type == models.SANCTION_MUTE && sanction.ExpiresAt == 0 {
+		return errors.New("a mute needs a duration")
+	}
+	sanctionByte, err := json.Marshal(sanction)
+	if err != nil {
+		return err
+	}
+	var expiration time.Duration
+	if sanction.ExpiresAt > 0 {
+		expiration = time.Until(time.Unix(sanction.ExpiresAt, 0))
+		if expiration <= 0 {
+			return errors.New("sanction is already expired")
+		}
+	}
+	return redisClient.Set(context.TODO(), sanctionKey(sanction.Type, sanction.UserId), sanctionByte, expiration).Err()
+}
+
+func RemoveSanction(userId string, sanctionType string, redisClient *redis.Client) error {
+	if !isSanctionType(sanctionType) {
+		return fmt.Errorf("unknown sanction type %q", sanctionType)
+	}
+	return redisClient.Del(context.TODO(), sanctionKey(sanctionType, userId)).Err()
+}
+
+func GetSanctions(userId string, redisClient *redis.Client) (models.UserSanctions, error) {
+	var userSanctions models.UserSanctions
+	values, err := redisClient.MGet(context.TODO(),
+		sanctionKey(models.SANCTION_BAN, userId),
+		sanctionKey(models.SANCTION_MUTE, userId),
+		sanctionKey(models.SANCTION_SHADOW, userId),
+	).Result()
+	if err != nil {
+		return userSanctions, err
+	}
+	targets := []**models.Sanction{&userSanctions.Ban, &userSanctions.Mute, &userSanctions.Shadow}
+	for i, value := range values {
+		raw, ok := value.(string)
+		if !ok {
+			continue
+		}
+		var sanction models.Sanction
+		err := json.Unmarshal([]byte(raw), &sanction)
+		if err != nil {
+			return userSanctions, err
+		}
+		*targets[i] = &sanction
+	}
+	return userSanctions, nil
+}
+
+func VerifyModerator(authToken string) (string, bool) {
+	if authToken == "" {
+		return "", false
+	}
+	tokenContent, err := DecodeJWT(authToken)
+	if err != nil {
+		return "", false
+	}
+	claims := tokenContent.Claims.(jwt.MapClaims)
+	role, _ := claims["role"].(string)
+	if role != models.ROLE_MODERATOR && role != models.ROLE_ADMIN {
+		return "", false
+	}
+	moderatorId, _ := claims["_id"].(string)
+	return moderatorId, true
+}
+
+// VerifyIntegration checks the token belongs to a trusted integration and returns its user id
+func VerifyIntegration(authToken string) (string, bool) {
+	if authToken == "" {
+		return "", false
+	}
+	tokenContent, err := DecodeJWT(authToken)
+	if err != nil {
+		return "", false
+	}
+	claims := tokenContent.Claims.(jwt.MapClaims)
+	role, _ := claims["role"].(string)
+	if role != models.ROLE_INTEGRATION && role != models.ROLE_ADMIN {
+		return "", false
+	}
+	integrationId, _ := claims["_id"].(string)
+	return integrationId, integrationId != ""
+}
+
+// #endregion Sanctions
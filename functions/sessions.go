@@ -0,0 +1,103 @@
+package functions
+
+import (
+	"canvas/models"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// #region Sessions
+
+func sessionsKey(userId string) string {
+	return "SESSIONS:" + userId
+}
+
+func NewSessionId() string {
+	id := make([]byte, 16)
+	rand.Read(id)
+	return hex.EncodeToString(id)
+}
+
+// registerSessionScript drops sessions whose heartbeat key expired, then applies the session limit.
+// KEYS[1] = user sessions, ARGV = session id, limit, kick oldest (1/0), heartbeat ttl in secs.
+// Returns {'1', kicked sessions...} when registered or {'0'} when rejected.
+var registerSessionScript = redis.NewScript(`
+for _, session in ipairs(redis.call('ZRANGE', KEYS[1], 0, -1)) do
+	if redis.call('EXISTS', 'SESSION:' .. session) == 0 then
+		redis.call('ZREM', KEYS[1], session)
+	end
+end
+local limit = tonumber(ARGV[2])
+local over = redis.call('ZCARD', KEYS[1]) - limit + 1
+local result = {'1'}
+if over > 0 then
+	if ARGV[3] ~= '1' then
+		return {'0'}
+	end
+	for _, session in ipairs(redis.call('ZRANGE', KEYS[1], 0, over - 1)) do
+		redis.call('ZREM', KEYS[1], session)
+		redis.call('DEL', 'SESSION:' .. session)
+		table.insert(result, session)
+	end
+end
+local time = redis.call('TIME')
+redis.call('ZADD', KEYS[1], tonumber(time[1]) * 1000 + math.floor(tonumber(time[2]) / 1000), ARGV[1])
+redis.call('SET', 'SESSION:' .. ARGV[1], '1', 'EX', ARGV[4])
+redis.call('EXPIRE', KEYS[1], ARGV[4])
+return result
+`)
+
+// RegisterSession records a new session for the user and enforces models.MAX_SESSIONS_PER_USER.
+// It returns false when the session is rejected, otherwise the sessions that have to be kicked.
+func RegisterSession(userId string, sessionId string, redisClient *redis.Client) (bool, []string, error) {
+	kickOldest := "0"
+	if models.SESSION_LIMIT_POLICY == models.SESSION_POLICY_KICK_OLDEST {
+		kickOldest = "1"
+	}
+	result, err := registerSessionScript.Run(context.TODO(), redisClient, []string{sessionsKey(userId)},
+		sessionId, models.MAX_SESSIONS_PER_USER, kickOldest, models.SESSION_TTL_SECS).StringSlice()
+	if err != nil {
+		return false, nil, err
+	}
+	if result[0] != "1" {
+		return false, nil, nil
+	}
+	return true, result[1:], nil
+}
+
+func UnregisterSession(userId string, sessionId string, redisClient *redis.Client) error {
+	pipe := redisClient.Pipeline()
+	pipe.ZRem(context.TODO(), sessionsKey(userId), sessionId)
+	pipe.Del(context.TODO(), "SESSION:"+sessionId)
+	_, err := pipe.Exec(context.TODO())
+	return err
+}
+
+// RefreshSessions keeps the heartbeat keys of live sessions from expiring
+func RefreshSessions(sessions map[string]string, redisClient *redis.Client) error {
+	if len(sessions) == 0 {
+		return nil
+	}
+	pipe := redisClient.Pipeline()
+	for sessionId, userId := range sessions {
+		pipe.Expire(context.TODO(), "SESSION:"+sessionId, time.Duration(models.SESSION_TTL_SECS)*time.Second)
+		pipe.Expire(context.TODO(), sessionsKey(userId), time.Duration(models.SESSION_TTL_SECS)*time.Second)
+	}
+	_, err := pipe.Exec(context.TODO())
+	return err
+}
+
+func KickSessions(sessionIds []string, redisClient *redis.Client) error {
+	pipe := redisClient.Pipeline()
+	for _, sessionId := range sessionIds {
+		pipe.Publish(context.TODO(), "sessionKicks", sessionId)
+	}
+	_, err := pipe.Exec(context.TODO())
+	return err
+}
+
+// #endregion Sessions
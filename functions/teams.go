@@ -0,0 +1,97 @@
+package functions
+
+import (
+	"canvas/catalogue"
+	"canvas/models"
+	"context"
+	"strconv"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/redis/go-redis/v9"
+)
+
+// #region Teams
+
+const teamsKey = "TEAMS"
+
+func teamStatsKey(canvasIdentifier string) string {
+	return "TEAM_STATS:" + canvasIdentifier
+}
+
+// TeamFromToken reads the team claim of a user token, empty when there is none
+func TeamFromToken(authToken string) string {
+	tokenContent, err := DecodeJWT(authToken)
+	if err != nil {
+		return ""
+	}
+	team, _ := tokenContent.Claims.(jwt.MapClaims)[models.TEAM_CLAIM].(string)
+	return team
+}
+
+// GetTeam returns the team assigned by an admin, falling back to the team claim of the user token
+func GetTeam(userId string, teamClaim string, redisClient *redis.Client) (string, error) {
+	team, err := redisClient.HGet(context.TODO(), teamsKey, userId).Result()
+	if err == redis.Nil {
+		return teamClaim, nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return team, nil
+}
+
+func SetTeam(userId string, team string, redisClient *redis.Client) error {
+	if team == "" {
+		return redisClient.HDel(context.TODO(), teamsKey, userId).Err()
+	}
+	return redisClient.HSet(context.TODO(), teamsKey, userId, team).Err()
+}
+
+// CanTeamPaint checks the regions of a canvas. Pixels outside every region can be painted by anyone,
+// pixels inside regions only by the teams of at least one of them.
+func CanTeamPaint(canvasIdentifier string, pixelId int32, team string) bool {
+	regions := catalogue.CANVAS_CONFIGS[canvasIdentifier].Regions
+	if len(regions) == 0 {
+		return true
+	}
+	width, _ := CanvasSize(canvasIdentifier)
+	x, y := pixelId%width, pixelId/width
+	inRegion := false
+	for _, region := range regions {
+		if x < region.X || x >= region.X+region.Width || y < region.Y || y >= region.Y+region.Height {
+			continue
+		}
+		inRegion = true
+		for _, allowed := range region.Teams {
+			if allowed == team {
+				return true
+			}
+		}
+	}
+	return !inRegion
+}
+
+func RecordTeamPlacement(canvasIdentifier string, team string, pixels int, redisClient *redis.Client) error {
+	if team == "" {
+		return nil
+	}
+	return redisClient.HIncrBy(context.TODO(), teamStatsKey(canvasIdentifier), team, int64(pixels)).Err()
+}
+
+func GetTeamStats(canvasIdentifier string, redisClient *redis.Client) (map[string]int64, error) {
+	stats := map[string]int64{}
+	values, err := redisClient.HGetAll(context.TODO(), teamStatsKey(canvasIdentifier)).Result()
+	if err != nil {
+		return stats, err
+	}
+	for team, value := range values {
+		placements, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return stats, err
+		}
+		stats[team] = placements
+	}
+	return stats, nil
+}
+
+// #endregion Teams
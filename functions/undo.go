@@ -0,0 +1,160 @@
+package functions
+
+import (
+	"canvas/models"
+	canvas "canvas/proto"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"google.golang.org/protobuf/proto"
+)
+
+// #region Undo
+
+func undoKey(canvasIdentifier string, userId string) string {
+	return fmt.Sprintf("UNDO:%s:%s", canvasIdentifier, userId)
+}
+
+// SaveUndo keeps the most recent placement of a user for UNDO_GRACE_SECS, replacing the one before it
+func SaveUndo(userId string, canvasIdentifier string, pixels []models.UndoPixel, redisClient *redis.Client) error {
+	if models.UNDO_GRACE_SECS <= 0 {
+		return nil
+	}
+	record, err := json.Marshal(models.UndoRecord{Pixels: pixels, PlacedAt: time.Now().Unix()})
+	if err != nil {
+		return err
+	}
+	return redisClient.Set(context.TODO(), undoKey(canvasIdentifier, userId), record, time.Duration(models.UNDO_GRACE_SECS)*time.Second).Err()
+}
+
+// UndoPlacement puts back what the most recent placement of a user covered and publishes it as an UpdateBatch.
+// Pixels someone else painted over since then are left alone. Returns the pixels that were put back,
+// or ErrCanvasResizing while the canvas is being resized.
+func UndoPlacement(userId string, canvasIdentifier string, origin models.EventOrigin, redisClient *redis.Client, mongoClient *mongo.Client) ([]models.UndoPixel, error) {
+	write, err := BeginPixelWrite(canvasIdentifier, redisClient)
+	if err != nil {
+		return nil, err
+	}
+	defer write.End()
+
+	value, err := redisClient.GetDel(context.TODO(), undoKey(canvasIdentifier, userId)).Result()
+	if err == redis.Nil {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var record models.UndoRecord
+	err = json.Unmarshal([]byte(value), &record)
+	if err != nil {
+		return nil, err
+	}
+
+	//#region restore history
+	collection := mongoClient.Database("canvas").Collection(canvasIdentifier)
+	restored := []models.UndoPixel{}
+	for _, pixel := range record.Pixels {
+		filter := bson.M{"pixelId": pixel.PixelId, "userId": userId}
+		if pixel.PreviousUserId == "" {
+			result, err := collection.DeleteOne(context.TODO(), filter)
+			if err != nil {
+				return restored, err
+			}
+			if result.DeletedCount == 0 {
+				continue
+			}
+		} else {
+			previousPixel := models.PixelData{
+				UserId:    pixel.PreviousUserId,
+				PixelId:   pixel.PixelId,
+				Color:     pixel.PreviousColor,
+				TimeStamp: pixel.PreviousTimeStamp,
+			}
+			result, err := collection.ReplaceOne(context.TODO(), filter, previousPixel)
+			if err != nil {
+				return restored, err
+			}
+			if result.MatchedCount == 0 {
+				continue
+			}
+		}
+		restored = append(restored, pixel)
+	}
+	if len(restored) == 0 {
+		return restored, nil
+	}
+	//#endregion restore history
+
+	//#region restore pixels
+	pixelIds := make([]int32, len(restored))
+	colors := make([]int32, len(restored))
+	pixels := make([]*canvas.Pixel, len(restored))
+	for i, pixel := range restored {
+		pixelIds[i], colors[i] = pixel.PixelId, pixel.PreviousColor
+		pixels[i] = &canvas.Pixel{PixelId: pixel.PixelId, Color: pixel.PreviousColor, UserId: pixel.PreviousUserId}
+	}
+	_, err = write.WritePixels(pixelIds, colors)
+	if err != nil {
+		return restored, err
+	}
+	//#endregion restore pixels
+
+	//#region publish
+	message := &canvas.ResponseMessage{
+		MessageType:      models.UpdateBatch,
+		Pixels:           pixels,
+		CanvasIdentifier: canvasIdentifier,
+		SessionId:        origin.SessionId,
+		RequestId:        origin.RequestId,
+	}
+	messageByte, err := proto.Marshal(message)
+	if err != nil {
+		return restored, err
+	}
+	pipe := redisClient.Pipeline()
+	for _, pixel := range restored {
+		pipe.Del(context.TODO(), PixelCooldownKey(canvasIdentifier, pixel.PixelId))
+	}
+	pipe.Publish(context.TODO(), "pixelUpdates", messageByte)
+	StreamUpdate(pipe, canvasIdentifier, messageByte)
+	_, err = pipe.Exec(context.TODO())
+	if err != nil {
+		return restored, err
+	}
+	//#endregion publish
+
+	for _, pixel := range restored {
+		err = UndoLeaderboards(canvasIdentifier, userId, pixel.PreviousUserId, redisClient)
+		if err != nil {
+			log.Println("Error undoing leaderboards:", err)
+		}
+	}
+	return restored, nil
+}
+
+// RefundUserCooldown takes UNDO_REFUND_PERCENT of the cooldown for the given number of pixels off the user cooldown
+func RefundUserCooldown(userId string, canvasIdentifier string, pixels int, redisClient *redis.Client) error {
+	key := UserCooldownKey(canvasIdentifier, userId)
+	remaining, err := CooldownRemaining(key, redisClient)
+	if err != nil || remaining == 0 {
+		return err
+	}
+	refund := time.Duration(pixels) * models.USER_COOLDOWN_PERIOD * time.Second * time.Duration(models.UNDO_REFUND_PERCENT) / 100
+	if remaining <= refund {
+		return redisClient.Del(context.TODO(), key).Err()
+	}
+	return redisClient.PExpire(context.TODO(), key, remaining-refund).Err()
+}
+
+// UndoCreditRefund is the number of credits given back for undoing the given number of pixels
+func UndoCreditRefund(pixels int) int {
+	return pixels * models.UNDO_REFUND_PERCENT / 100
+}
+
+// #endregion Undo
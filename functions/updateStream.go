@@ -0,0 +1,152 @@
+package functions
+
+import (
+	"canvas/catalogue"
+	"canvas/models"
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// #region Update Stream
+
+// Every published update is also appended to the UPDATES:<canvas> stream. The stream ids are the
+// sequence numbers SSE clients resume from with Last-Event-ID.
+
+func updateStreamKey(canvasIdentifier string) string {
+	return "UPDATES:" + canvasIdentifier
+}
+
+// StreamUpdate appends a published update to the update stream of the canvas, trimming it to about UPDATE_STREAM_LENGTH
+func StreamUpdate(pipe redis.Pipeliner, canvasIdentifier string, messageByte []byte) {
+	pipe.XAdd(context.TODO(), &redis.XAddArgs{
+		Stream: updateStreamKey(canvasIdentifier),
+		MaxLen: int64(models.UPDATE_STREAM_LENGTH),
+		Approx: true,
+		Values: map[string]interface{}{"message": messageByte},
+	})
+}
+
+// LatestUpdateId returns the sequence number of the last update of the canvas, "0" when there is none
+func LatestUpdateId(canvasIdentifier string, redisClient *redis.Client) (string, error) {
+	latest, err := redisClient.XRevRangeN(context.TODO(), updateStreamKey(canvasIdentifier), "+", "-", 1).Result()
+	if err != nil {
+		return "", err
+	}
+	if len(latest) == 0 {
+		return "0", nil
+	}
+	return latest[0].ID, nil
+}
+
+// UpdatesSince returns the updates after lastId. complete is false when updates after lastId were already
+// trimmed from the stream and the client has to start over from a snapshot.
+func UpdatesSince(canvasIdentifier string, lastId string, redisClient *redis.Client) (updates []models.StreamEvent, complete bool, err error) {
+	_, _, err = parseUpdateId(lastId)
+	if err != nil {
+		return nil, false, err
+	}
+	key := updateStreamKey(canvasIdentifier)
+	first, err := redisClient.XRangeN(context.TODO(), key, "-", "+", 1).Result()
+	if err != nil {
+		return nil, false, err
+	}
+	if len(first) > 0 && CompareUpdateIds(lastId, first[0].ID) < 0 {
+		return nil, false, nil
+	}
+	messages, err := redisClient.XRange(context.TODO(), key, "("+lastId, "+").Result()
+	if err != nil {
+		return nil, false, err
+	}
+	for _, message := range messages {
+		updates = append(updates, toStreamEvent(message))
+	}
+	return updates, true, nil
+}
+
+// TailUpdates follows the update streams of every canvas and hands each new update to forward
+func TailUpdates(redisClient *redis.Client, forward func(canvasIdentifier string, event models.StreamEvent)) {
+	lastIds := map[string]string{}
+	for {
+		streams := []string{}
+		ids := []string{}
+		for _, canvasIdentifier := range catalogue.CANVAS_LIST {
+			if _, found := lastIds[canvasIdentifier]; !found {
+				latest, err := LatestUpdateId(canvasIdentifier, redisClient)
+				if err != nil {
+					log.Println("Error reading update stream:", err)
+					continue
+				}
+				lastIds[canvasIdentifier] = latest
+			}
+			streams = append(streams, updateStreamKey(canvasIdentifier))
+			ids = append(ids, lastIds[canvasIdentifier])
+		}
+		if len(streams) == 0 {
+			time.Sleep(time.Second)
+			continue
+		}
+
+		result, err := redisClient.XRead(context.TODO(), &redis.XReadArgs{
+			Streams: append(streams, ids...),
+			Block:   time.Duration(models.PING_INTERVAL) * time.Second,
+		}).Result()
+		if err == redis.Nil {
+			continue
+		}
+		if err != nil {
+			log.Println("Error reading update stream:", err)
+			time.Sleep(time.Second)
+			continue
+		}
+		for _, stream := range result {
+			canvasIdentifier := strings.TrimPrefix(stream.Stream, "UPDATES:")
+			for _, message := range stream.Messages {
+				lastIds[canvasIdentifier] = message.ID
+				forward(canvasIdentifier, toStreamEvent(message))
+			}
+		}
+	}
+}
+
+func toStreamEvent(message redis.XMessage) models.StreamEvent {
+	payload, _ := message.Values["message"].(string)
+	return models.StreamEvent{Id: message.ID, Payload: []byte(payload)}
+}
+
+// CompareUpdateIds orders two sequence numbers like strings.Compare, invalid ids sort first
+func CompareUpdateIds(a string, b string) int {
+	aMs, aSeq, _ := parseUpdateId(a)
+	bMs, bSeq, _ := parseUpdateId(b)
+	switch {
+	case aMs < bMs || (aMs == bMs && aSeq < bSeq):
+		return -1
+	case aMs > bMs || aSeq > bSeq:
+		return 1
+	}
+	return 0
+}
+
+// parseUpdateId splits a stream id like "1718000000000-3", a bare "1718000000000" is sequence 0
+func parseUpdateId(id string) (uint64, uint64, error) {
+	ms, seq, hasSeq := strings.Cut(id, "-")
+	msValue, err := strconv.ParseUint(ms, 10, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid update id %q", id)
+	}
+	if !hasSeq {
+		return msValue, 0, nil
+	}
+	seqValue, err := strconv.ParseUint(seq, 10, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid update id %q", id)
+	}
+	return msValue, seqValue, nil
+}
+
+// #endregion Update Stream
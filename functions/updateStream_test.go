@@ -0,0 +1,29 @@
+package functions
+
+import "testing"
+
+func TestCompareUpdateIds(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+		want int
+	}{
+		{name: "equal", a: "1718000000000-3", b: "1718000000000-3", want: 0},
+		{name: "bare id equals sequence 0", a: "1718000000000", b: "1718000000000-0", want: 0},
+		{name: "smaller sequence", a: "1718000000000-2", b: "1718000000000-10", want: -1},
+		{name: "larger sequence", a: "1718000000000-10", b: "1718000000000-2", want: 1},
+		{name: "smaller time", a: "1717999999999-99", b: "1718000000000-0", want: -1},
+		{name: "larger time", a: "1718000000001-0", b: "1718000000000-99", want: 1},
+		{name: "more digits", a: "999999999999-0", b: "1000000000000-0", want: -1},
+		{name: "invalid sorts first", a: "latest", b: "0-1", want: -1},
+		{name: "invalid after a valid id", a: "1-0", b: "1-x", want: 1},
+		{name: "empty", a: "", b: "", want: 0},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := CompareUpdateIds(test.a, test.b); got != test.want {
+				t.Fatalf("CompareUpdateIds(%q, %q) = %v, want %v", test.a, test.b, got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,355 @@
+package main
+
+import (
+	"canvas/catalogue"
+	"canvas/connections"
+	"canvas/functions"
+	"canvas/models"
+	canvas "canvas/proto"
+	"context"
+	"log"
+	"net"
+	"strings"
+
+	"github.com/redis/go-redis/v9"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// #region gRPC
+
+// canvasServer serves the CanvasService with the same checks as the websocket
+type canvasServer struct {
+	canvas.UnimplementedCanvasServiceServer
+}
+
+// grpcCaller is who makes a call, spectators have no user id
+type grpcCaller struct {
+	UserId    string
+	TeamClaim string
+	IP        string
+	Spectator bool
+}
+
+// listenGRPC binds GRPC_ADDR and serves the CanvasService in the background
+func listenGRPC() error {
+	listener, err := net.Listen("tcp", models.GRPC_ADDR)
+	if err != nil {
+		return err
+	}
+	server := grpc.NewServer(grpc.MaxRecvMsgSize(models.MAX_MESSAGE_SIZE))
+	canvas.RegisterCanvasServiceServer(server, &canvasServer{})
+	go func() {
+		err := server.Serve(listener)
+		if err != nil {
+			log.Println("ERR79: ", err)
+		}
+	}()
+	return nil
+}
+
+// authorize checks a call like a websocket handshake followed by a message: the canvas, spectator access,
+// the rate limit, the user token and bans
+func authorize(ctx context.Context, canvasIdentifier string, spectatorAllowed bool, buckets func(ip string, userId string) []functions.Bucket) (grpcCaller, error) {
+	var caller grpcCaller
+	if !functions.CanvasExists(catalogue.CANVAS_LIST, canvasIdentifier) {
+		return caller, status.Error(codes.NotFound, "Invalid Canvas Identifier")
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	caller.UserId = firstValue(md, "user-id")
+	xAuthToken := firstValue(md, "x-auth-token")
+	caller.IP = callerIP(ctx, md)
+
+	// calls without a user id and token are read-only spectators
+	caller.Spectator = caller.UserId == "" && xAuthToken == ""
+	if caller.Spectator && !models.ALLOW_SPECTATORS {
+		return caller, status.Error(codes.Unauthenticated, "Unauthorized")
+	}
+	if caller.Spectator && !spectatorAllowed {
+		return caller, status.Error(codes.PermissionDenied, "Spectators can only view the canvas!")
+	}
+
+	// the user bucket is only taken once the token proves the user id, anyone could drain it otherwise
+	err := takeCallTokens(buckets(caller.IP, ""))
+	if err != nil || caller.Spectator {
+		return caller, err
+	}
+
+	_, err = primitive.ObjectIDFromHex(caller.UserId)
+	if err != nil {
+		return caller, status.Error(codes.InvalidArgument, "Invalid User ID")
+	}
+	if !functions.VerifyUser(caller.UserId, xAuthToken) {
+		return caller, status.Error(codes.Unauthenticated, "Unauthorized")
+	}
+	err = takeCallTokens(buckets("", caller.UserId))
+	if err != nil {
+		return caller, err
+	}
+	sanctions, err := functions.GetSanctions(caller.UserId, connections.RedisClient)
+	if err != nil {
+		log.Println("ERR81: ", err)
+		return caller, status.Error(codes.Internal, "Error checking sanctions!")
+	}
+	if sanctions.Ban != nil {
+		return caller, rejectionError(codes.PermissionDenied, &canvas.Rejection{Reason: canvas.Reason_BANNED, Message: "Banned"})
+	}
+	caller.TeamClaim = functions.TeamFromToken(xAuthToken)
+	return caller, nil
+}
+
+// takeCallTokens fails a call with a THROTTLED rejection when the buckets are empty
+func takeCallTokens(buckets []functions.Bucket) error {
+	allowed, retryAfter, err := functions.TakeTokens(buckets, 1, connections.RedisClient)
+	if err != nil {
+		log.Println("ERR80: ", err)
+	} else if !allowed {
+		rejection := &canvas.Rejection{Reason: canvas.Reason_THROTTLED, Message: "Too many requests!", RetryAfterMs: retryAfter.Milliseconds()}
+		return rejectionError(codes.ResourceExhausted, rejection)
+	}
+	return nil
+}
+
+// messageBuckets rate limits a call like the websocket message of the given type
+func messageBuckets(messageType int32) func(ip string, userId string) []functions.Bucket {
+	return func(ip string, userId string) []functions.Bucket {
+		return functions.MessageBuckets(ip, userId, messageType)
+	}
+}
+
+func firstValue(md metadata.MD, key string) string {
+	values := md.Get(key)
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+func callerIP(ctx context.Context, md metadata.MD) string {
+	if models.TRUST_PROXY_HEADERS {
+		forwardedFor := firstValue(md, "x-forwarded-for")
+		if forwardedFor != "" {
+			return strings.TrimSpace(strings.Split(forwardedFor, ",")[0])
+		}
+	}
+	caller, ok := peer.FromContext(ctx)
+	if !ok {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(caller.Addr.String())
+	if err != nil {
+		return caller.Addr.String()
+	}
+	return host
+}
+
+// rejectionError fails a call with the Rejection a websocket client would get as detail
+func rejectionError(code codes.Code, rejection *canvas.Rejection) error {
+	rejected := status.New(code, rejection.GetMessage())
+	detailed, err := rejected.WithDetails(rejection)
+	if err != nil {
+		return rejected.Err()
+	}
+	return detailed.Err()
+}
+
+// rejectionCode maps the reason a placement was refused to its status code
+func rejectionCode(reason canvas.Reason) codes.Code {
+	switch reason {
+	case canvas.Reason_INVALID_BRUSH, canvas.Reason_INVALID_PIXEL, canvas.Reason_INVALID_MESSAGE:
+		return codes.InvalidArgument
+	case canvas.Reason_BANNED, canvas.Reason_MUTED, canvas.Reason_TEAM_REGION:
+		return codes.PermissionDenied
+	case canvas.Reason_CANVAS_NOT_OPEN, canvas.Reason_CANVAS_RESIZING:
+		return codes.FailedPrecondition
+	case canvas.Reason_USER_COOLDOWN, canvas.Reason_PIXEL_COOLDOWN, canvas.Reason_NOT_ENOUGH_CREDITS, canvas.Reason_THROTTLED:
+		return codes.ResourceExhausted
+	}
+	return codes.Internal
+}
+
+// #region Unary
+
+func (s *canvasServer) GetConfig(ctx context.Context, request *canvas.CanvasRequest) (*canvas.Config, error) {
+	caller, err := authorize(ctx, request.GetCanvasIdentifier(), true, messageBuckets(models.GET_CONFIG))
+	if err != nil {
+		return nil, err
+	}
+	response, _ := functions.ConfigResponse(request.GetCanvasIdentifier(), caller.UserId, connections.RedisClient)
+	return functions.ToEnvelope(response, &canvas.RequestMessage{MessageType: models.GET_CONFIG}).GetConfig(), nil
+}
+
+func (s *canvasServer) GetCanvas(ctx context.Context, request *canvas.CanvasRequest) (*canvas.CanvasSnapshot, error) {
+	_, err := authorize(ctx, request.GetCanvasIdentifier(), true, messageBuckets(models.GET_CANVAS))
+	if err != nil {
+		return nil, err
+	}
+	// a canvas nobody placed on yet has no bitfield, GetCanvas then returns it empty
+	pixels, err := functions.GetCanvas(request.GetCanvasIdentifier(), connections.RedisClient)
+	if err != nil && err != redis.Nil {
+		log.Println("ERR82: ", err)
+		return nil, status.Error(codes.Internal, "Error getting canvas!")
+	}
+	return &canvas.CanvasSnapshot{Canvas: pixels, CanvasIdentifier: request.GetCanvasIdentifier()}, nil
+}
+
+func (s *canvasServer) GetRegion(ctx context.Context, request *canvas.RegionRequest) (*canvas.CanvasRegion, error) {
+	_, err := authorize(ctx, request.GetCanvasIdentifier(), true, messageBuckets(models.GET_CANVAS))
+	if err != nil {
+		return nil, err
+	}
+	region, err := functions.GetRegion(request.GetCanvasIdentifier(), request.GetX(), request.GetY(), request.GetWidth(), request.GetHeight(), connections.RedisClient)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return &canvas.CanvasRegion{X: region.X, Y: region.Y, Width: region.Width, Height: region.Height, Pixels: region.Pixels}, nil
+}
+
+func (s *canvasServer) GetPixel(ctx context.Context, request *canvas.PixelRequest) (*canvas.PixelInfo, error) {
+	_, err := authorize(ctx, request.GetCanvasIdentifier(), false, messageBuckets(models.VIEW_PIXEL))
+	if err != nil {
+		return nil, err
+	}
+	width, height := functions.CanvasSize(request.GetCanvasIdentifier())
+	if request.GetPixelId() < 0 || request.GetPixelId() >= width*height {
+		return nil, status.Error(codes.InvalidArgument, "Invalid Pixel ID")
+	}
+	pixelData, _, err := functions.GetPixelData(request.GetPixelId(), request.GetCanvasIdentifier(), connections.MongoClient)
+	if err != nil {
+		log.Println("ERR83: ", err)
+		return nil, status.Error(codes.Internal, "Error getting pixel!")
+	}
+	return &canvas.PixelInfo{PixelId: pixelData.PixelId, Color: pixelData.Color, UserId: pixelData.UserId}, nil
+}
+
+// PlacePixels places like SET_CANVAS or SET_PIXELS, refusals fail with the Rejection as detail
+func (s *canvasServer) PlacePixels(ctx context.Context, place *canvas.PlaceRequest) (*canvas.PlaceResponse, error) {
+	var request *canvas.RequestMessage
+	switch placement := place.GetPlacement().(type) {
+	case *canvas.PlaceRequest_SetPixel:
+		request = &canvas.RequestMessage{
+			MessageType: models.SET_CANVAS,
+			PixelId:     placement.SetPixel.GetPixelId(),
+			Color:       placement.SetPixel.GetColor(),
+		}
+	case *canvas.PlaceRequest_SetPixels:
+		request = &canvas.RequestMessage{
+			MessageType: models.SET_PIXELS,
+			Brush:       placement.SetPixels.GetBrush(),
+			PixelIds:    placement.SetPixels.GetPixelIds(),
+			PixelId:     placement.SetPixels.GetPixelId(),
+			ToPixelId:   placement.SetPixels.GetToPixelId(),
+			BrushSize:   placement.SetPixels.GetBrushSize(),
+			Color:       placement.SetPixels.GetColor(),
+		}
+	default:
+		return nil, status.Error(codes.InvalidArgument, "Nothing to place")
+	}
+	request.RequestId = place.GetRequestId()
+
+	caller, err := authorize(ctx, place.GetCanvasIdentifier(), false, messageBuckets(request.GetMessageType()))
+	if err != nil {
+		return nil, err
+	}
+	placer := models.Placer{
+		UserId:           caller.UserId,
+		TeamClaim:        caller.TeamClaim,
+		CanvasIdentifier: place.GetCanvasIdentifier(),
+		Origin:           models.EventOrigin{RequestId: request.GetRequestId()},
+	}
+	result := functions.PlacePixels(request, placer, connections.RedisClient, connections.MongoClient)
+	envelope := functions.ToEnvelope(result.Response, request)
+	if rejection := envelope.GetRejection(); rejection != nil {
+		return nil, rejectionError(rejectionCode(rejection.GetReason()), rejection)
+	}
+
+	response := &canvas.PlaceResponse{Placed: envelope.GetPlaced()}
+	if result.Credits != nil {
+		response.Credits = &canvas.CreditBalance{
+			PixelsAvailable: int32(result.Credits.Balance),
+			NextPixelAt:     result.Credits.NextRefillAt,
+		}
+	}
+	return response, nil
+}
+
+// #endregion Unary
+
+// #region Streaming
+
+// StreamUpdates follows the updates of a canvas like the SSE stream, resuming after LastUpdateId
+func (s *canvasServer) StreamUpdates(request *canvas.StreamRequest, stream canvas.CanvasService_StreamUpdatesServer) error {
+	_, err := authorize(stream.Context(), request.GetCanvasIdentifier(), true, functions.UpgradeBuckets)
+	if err != nil {
+		return err
+	}
+	client := &models.StreamClient{
+		CanvasIdentifier: request.GetCanvasIdentifier(),
+		Protocol:         models.Protocol{Version: models.PROTOCOL_V2, Format: models.FORMAT_PROTOBUF},
+		Events:           make(chan models.StreamEvent, models.STREAM_CLIENT_BUFFER),
+	}
+	lastId, snapshot, backlog, err := openUpdateStream(client, request.GetLastUpdateId(), request.GetSnapshot())
+	defer streamClients.Delete(client)
+	if err != nil {
+		log.Println("ERR84: ", err)
+		return status.Error(codes.Internal, "Error reading updates!")
+	}
+
+	if snapshot != nil {
+		err = stream.Send(&canvas.CanvasUpdate{
+			UpdateId: lastId,
+			Event: &canvas.ServerEnvelope{Payload: &canvas.ServerEnvelope_Canvas{Canvas: &canvas.CanvasSnapshot{
+				Canvas:           snapshot.Pixels,
+				CanvasIdentifier: request.GetCanvasIdentifier(),
+			}}},
+		})
+		if err != nil {
+			return err
+		}
+	}
+	for _, event := range backlog {
+		encoder, err := functions.NewEventEncoder(event.Payload)
+		if err != nil {
+			log.Println("ERR85: ", err)
+			continue
+		}
+		err = stream.Send(&canvas.CanvasUpdate{UpdateId: event.Id, Event: functions.ToEnvelope(encoder.Event(), nil)})
+		if err != nil {
+			return err
+		}
+	}
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case event, open := <-client.Events:
+			if !open {
+				return status.Error(codes.Unavailable, "Too slow to keep up, resume from the last UpdateId")
+			}
+			if functions.CompareUpdateIds(event.Id, lastId) <= 0 {
+				continue
+			}
+			var envelope canvas.ServerEnvelope
+			err := proto.Unmarshal(event.Payload, &envelope)
+			if err != nil {
+				log.Println("ERR85: ", err)
+				continue
+			}
+			err = stream.Send(&canvas.CanvasUpdate{UpdateId: event.Id, Event: &envelope})
+			if err != nil {
+				return err
+			}
+			lastId = event.Id
+		}
+	}
+}
+
+// #endregion Streaming
+
+// #endregion gRPC
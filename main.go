@@ -9,6 +9,7 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"math"
 	"net/http"
 	"sync"
 	"time"
@@ -16,18 +17,27 @@ import (
 	"github.com/gorilla/websocket"
 	"github.com/redis/go-redis/v9"
 	"go.mongodb.org/mongo-driver/bson/primitive"
-	"google.golang.org/protobuf/proto"
 )
 
 var upgrader = websocket.Upgrader{
-	ReadBufferSize:  1024,
-	WriteBufferSize: 1024,
+	ReadBufferSize:   1024,
+	WriteBufferSize:  1024,
+	HandshakeTimeout: models.HANDSHAKE_TIMEOUT,
+	Subprotocols:     models.SUBPROTOCOLS,
+	CheckOrigin:      functions.CheckOrigin,
+	Error: func(w http.ResponseWriter, r *http.Request, status int, reason error) {
+		rejectHandshake(w, r, status, "upgrade_failed", reason.Error())
+	},
 }
 
 var clients = &sync.Map{}
 
 func main() {
 
+	if models.JWT_SECRET == "" {
+		log.Fatal("JWT_SECRET is not set, it has to hold the secret the auth service signs user tokens with")
+	}
+
 	// Redis Live Check
 	pong, err := connections.RedisClient.Ping(context.TODO()).Result()
 	if err != nil {
@@ -48,70 +58,239 @@ func main() {
 	defer pubsub.Close()
 	redisSubChan := pubsub.Channel(redis.WithChannelSize(5000))
 
+	// Subscribe to the sessionKicks channel
+	kickPubsub := connections.RedisClient.Subscribe(context.TODO(), "sessionKicks")
+	defer kickPubsub.Close()
+	kickSubChan := kickPubsub.Channel()
+
+	// Subscribe to the canvasEvents channel
+	canvasPubsub := connections.RedisClient.Subscribe(context.TODO(), "canvasEvents")
+	defer canvasPubsub.Close()
+	canvasSubChan := canvasPubsub.Channel()
+
+	err = catalogue.LoadCanvases(models.CANVAS_CONFIG_FILE)
+	if err != nil {
+		panic(fmt.Sprintf("Error loading canvases: %v", err))
+	}
+	err = functions.LoadMasks(connections.MongoClient)
+	if err != nil {
+		panic(fmt.Sprintf("Error loading masks: %v", err))
+	}
+	log.Printf("Loaded canvases %v with %v masks\n", catalogue.CANVAS_LIST, len(catalogue.MASKS))
+
+	err = functions.LoadCanvasSizes(connections.RedisClient)
+	if err != nil {
+		panic(fmt.Sprintf("Error loading canvas sizes: %v", err))
+	}
+
 	err = functions.MakeDefaultCanvas(connections.RedisClient)
 	if err != nil {
 		panic(fmt.Sprintf("Error making default canvas: %v", err))
 	}
 
+	_, err = functions.RefreshLifecycles(connections.RedisClient)
+	if err != nil {
+		panic(fmt.Sprintf("Error loading canvas lifecycles: %v", err))
+	}
+
 	go broadcastRedisMessages(redisSubChan, clients)
+	go kickSessions(kickSubChan, clients)
+	go broadcastCanvasEvents(canvasSubChan, clients)
 	go startPingPongChecker()
+	go startLifecycleWatcher()
+	go functions.TailUpdates(connections.RedisClient, forwardStreamUpdate)
+
+	if models.GRPC_ADDR != "" {
+		err = listenGRPC()
+		if err != nil {
+			panic(fmt.Sprintf("Error starting gRPC: %v", err))
+		}
+		log.Println("Serving gRPC on", models.GRPC_ADDR)
+	}
+
+	http.HandleFunc("GET /stats", getStats)
+	http.HandleFunc("GET /canvases/{canvasIdentifier}/teams", getTeamStats)
+	http.HandleFunc("GET /canvases/{canvasIdentifier}/leaderboard", getLeaderboard)
+	http.HandleFunc("GET /canvases/{canvasIdentifier}/palette", getPalette)
+	http.HandleFunc("GET /canvases/{canvasIdentifier}/canvas.png", getCanvasPNG)
+	http.HandleFunc("GET /canvases", listCanvases)
+	http.HandleFunc("GET /canvases/{canvasIdentifier}", getCanvasInfo)
+	http.HandleFunc("GET /canvases/{canvasIdentifier}/snapshot", getSnapshot)
+	http.HandleFunc("GET /canvases/{canvasIdentifier}/region", getRegion)
+	http.HandleFunc("GET /canvases/{canvasIdentifier}/pixels/{pixelId}", getPixelOwner)
+	http.HandleFunc("POST /canvases/{canvasIdentifier}/pixels", placePixels)
+	http.HandleFunc("GET /canvases/{canvasIdentifier}/events", streamUpdates)
+	http.HandleFunc("GET /admin/sanctions", getSanctions)
+	http.HandleFunc("POST /admin/sanctions", setSanction)
+	http.HandleFunc("DELETE /admin/sanctions", removeSanction)
+	http.HandleFunc("GET /admin/canvases/{canvasIdentifier}/lifecycle", getLifecycle)
+	http.HandleFunc("PUT /admin/canvases/{canvasIdentifier}/lifecycle", setLifecycle)
+	http.HandleFunc("POST /admin/canvases/{canvasIdentifier}/resize", resizeCanvas)
+	http.HandleFunc("PUT /admin/teams", setTeam)
 
 	http.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		//#region Handshake checks
+		if !functions.CheckOrigin(r) {
+			rejectHandshake(w, r, http.StatusForbidden, "origin_not_allowed", "Origin not allowed")
+			return
+		}
+		if models.REJECT_UNKNOWN_SUBPROTOCOLS && functions.HasUnknownSubprotocol(r) {
+			rejectHandshake(w, r, http.StatusBadRequest, "unknown_subprotocol", "Unsupported subprotocol")
+			return
+		}
+		if _, err := functions.NegotiateProtocol("", r.URL.Query().Get("version"), r.URL.Query().Get("format")); err != nil {
+			rejectHandshake(w, r, http.StatusBadRequest, "unsupported_protocol", "Unsupported protocol version or format")
+			return
+		}
+		//#endregion Handshake checks
+
 		//#region User Auth
 		userId := r.URL.Query().Get("userId")
 		canvasIdentifier := r.URL.Query().Get("canvasIdentifier")
+		xAuthToken := r.Header.Get("X-Auth-Token")
 		validCanvas := functions.CanvasExists(catalogue.CANVAS_LIST, canvasIdentifier)
 		if !validCanvas {
-			w.WriteHeader(http.StatusBadRequest)
-			w.Write([]byte("Invalid Canvas Identifier"))
+			rejectHandshake(w, r, http.StatusBadRequest, "invalid_canvas", "Invalid Canvas Identifier")
 			return
 		}
-		_, err := primitive.ObjectIDFromHex(userId)
-		if err != nil {
-			w.WriteHeader(http.StatusBadRequest)
-			w.Write([]byte("Invalid User ID"))
+		// connections without a user id and token join as read-only spectators
+		isSpectator := userId == "" && xAuthToken == ""
+		if isSpectator && !models.ALLOW_SPECTATORS {
+			rejectHandshake(w, r, http.StatusUnauthorized, "spectators_disabled", "Unauthorized")
 			return
 		}
-		xAuthToken := r.Header.Get("X-Auth-Token")
-		isValidUser := functions.VerifyUser(userId, xAuthToken)
-		if !isValidUser {
-			w.WriteHeader(http.StatusUnauthorized)
-			w.Write([]byte("Unauthorized"))
+		if !isSpectator {
+			_, err := primitive.ObjectIDFromHex(userId)
+			if err != nil {
+				rejectHandshake(w, r, http.StatusBadRequest, "invalid_user_id", "Invalid User ID")
+				return
+			}
+		}
+		// the user bucket is only taken once the token proves the user id, anyone could drain it otherwise
+		ip := functions.ClientIP(r)
+		allowed, retryAfter, err := functions.TakeTokens(functions.UpgradeBuckets(ip, ""), 1, connections.RedisClient)
+		if err != nil {
+			log.Println("ERR27: ", err)
+		} else if !allowed {
+			w.Header().Set("Retry-After", fmt.Sprint(int(math.Ceil(retryAfter.Seconds()))))
+			rejectHandshake(w, r, http.StatusTooManyRequests, "rate_limited", "Too many requests")
 			return
 		}
+		if !isSpectator {
+			isValidUser := functions.VerifyUser(userId, xAuthToken)
+			if !isValidUser {
+				rejectHandshake(w, r, http.StatusUnauthorized, "unauthorized", "Unauthorized")
+				return
+			}
+			allowed, retryAfter, err := functions.TakeTokens(functions.UpgradeBuckets("", userId), 1, connections.RedisClient)
+			if err != nil {
+				log.Println("ERR27: ", err)
+			} else if !allowed {
+				w.Header().Set("Retry-After", fmt.Sprint(int(math.Ceil(retryAfter.Seconds()))))
+				rejectHandshake(w, r, http.StatusTooManyRequests, "rate_limited", "Too many requests")
+				return
+			}
+			sanctions, err := functions.GetSanctions(userId, connections.RedisClient)
+			if err != nil {
+				log.Println("ERR20: ", err)
+				rejectHandshake(w, r, http.StatusInternalServerError, "sanction_check_failed", "Error checking sanctions!")
+				return
+			}
+			if sanctions.Ban != nil {
+				rejectHandshake(w, r, http.StatusForbidden, "banned", "Banned")
+				return
+			}
+		}
 		//#endregion User Auth
 
 		//#region Upgrade the HTTP connection to a websocket
-		websocket, err := upgrader.Upgrade(w, r, nil)
+		// upgrader.Error has already answered and logged the request when upgrading fails
+		conn, err := upgrader.Upgrade(w, r, nil)
 		if err != nil {
-			log.Println("ERR0: ", err)
-			w.WriteHeader(http.StatusInternalServerError)
-			w.Write([]byte("Error upgrading to websocket!"))
 			return
 		}
+		conn.SetReadLimit(int64(models.MAX_MESSAGE_SIZE))
+		protocol, _ := functions.NegotiateProtocol(conn.Subprotocol(), r.URL.Query().Get("version"), r.URL.Query().Get("format"))
 		client := &models.Client{
-			Conn:             websocket,
-			ServerChan:       make(chan []byte),
-			RedisChan:        make(chan []byte),
-			LastPong:         time.Now(),
-			UserId:           userId,
-			CanvasIdentifier: canvasIdentifier,
+			Conn:       conn,
+			ServerChan: make(chan []byte),
+			RedisChan:  make(chan []byte),
+			LastPong:   time.Now(),
+			UserId:     userId,
+			IP:         ip,
+			Spectator:  isSpectator,
+			SessionId:  functions.NewSessionId(),
+			Protocol:   protocol,
+		}
+		client.JoinCanvas(canvasIdentifier, false)
+		if !isSpectator {
+			client.TeamClaim = functions.TeamFromToken(xAuthToken)
 		}
-		go client.WriteEvents()
 		//#endregion Upgrade the HTTP connection to a websocket
 
+		//#region Session limit
+		if !client.Spectator {
+			registered, kickedSessions, err := functions.RegisterSession(client.UserId, client.SessionId, connections.RedisClient)
+			if err != nil {
+				log.Println("ERR31: ", err)
+			} else if !registered {
+				log.Printf("User %v is over the session limit, rejecting connection\n", client.UserId)
+				closeClient(client, websocket.ClosePolicyViolation, "Too many active sessions")
+				return
+			}
+			if len(kickedSessions) > 0 {
+				err := functions.KickSessions(kickedSessions, connections.RedisClient)
+				if err != nil {
+					log.Println("ERR32: ", err)
+				}
+			}
+		}
+		//#endregion Session limit
+
+		go client.WriteEvents()
+
+		if functions.UsesCredits(canvasIdentifier) && !client.Spectator {
+			credits, err := functions.GetCredits(client.UserId, connections.RedisClient)
+			if err != nil {
+				log.Println("ERR41: ", err)
+			} else {
+				sendCredits(client, credits)
+			}
+		}
+
 		clients.Store(client, true)
 
 		go listen(client)
 	})
-	http.ListenAndServe(":8080", nil)
+	server := &http.Server{
+		Addr:              ":8080",
+		ReadHeaderTimeout: models.READ_HEADER_TIMEOUT,
+	}
+	server.ListenAndServe()
+}
+
+// rejectHandshake refuses a websocket handshake and logs the reason with the request details
+func rejectHandshake(w http.ResponseWriter, r *http.Request, status int, reason string, message string) {
+	log.Printf("handshake rejected: reason=%s status=%d ip=%s origin=%q subprotocols=%q userId=%q canvasIdentifier=%q message=%q\n",
+		reason, status, functions.ClientIP(r), r.Header.Get("Origin"), websocket.Subprotocols(r),
+		r.URL.Query().Get("userId"), r.URL.Query().Get("canvasIdentifier"), message)
+	w.WriteHeader(status)
+	w.Write([]byte(message))
 }
 
 func listen(client *models.Client) {
 
 	//log the disconnect message if recieved by socket connection
 	defer func() {
-		log.Printf("User %v is disconnected!\n", client.UserId)
+		if client.Spectator {
+			log.Printf("Spectator %v is disconnected!\n", client.IP)
+		} else {
+			log.Printf("User %v is disconnected!\n", client.UserId)
+			err := functions.UnregisterSession(client.UserId, client.SessionId, connections.RedisClient)
+			if err != nil {
+				log.Println("ERR33: ", err)
+			}
+		}
 		client.Conn.Close()
 		clients.Delete(client)
 	}()
@@ -127,12 +306,7 @@ func listen(client *models.Client) {
 				MessageType: models.Error,
 				Message:     "Pong!",
 			}
-			protoMessage, err := proto.Marshal(response)
-			if err != nil {
-				log.Println("ERR1: ", err)
-				continue
-			}
-			client.ServerChan <- protoMessage
+			send(client, nil, response)
 		}
 		if messageType == websocket.CloseMessage || messageType == -1 {
 			client.Conn.Close()
@@ -143,37 +317,24 @@ func listen(client *models.Client) {
 			log.Println("ERR2: ", err)
 			response := &canvas.ResponseMessage{
 				MessageType: models.Error,
-				Message:     "ERR4: Internal server error!",
-			}
-			protoMessage, err := proto.Marshal(response)
-			if err != nil {
-				log.Println("ERR3: ", err)
-				client.Conn.Close()
-				clients.Delete(client)
-				return
+				Reason:      canvas.Reason_INTERNAL_ERROR,
+				Message:     "Internal server error!",
 			}
-			client.ServerChan <- protoMessage
+			send(client, nil, response)
 			client.Conn.Close()
 			clients.Delete(client)
 			return
 		}
 
-		var userMessage canvas.RequestMessage
-		err = proto.Unmarshal(messageContent, &userMessage)
+		userMessage, err := functions.DecodeRequest(messageContent, client.Protocol)
 		if err != nil {
 			log.Println("ERR4: ", err)
 			response := &canvas.ResponseMessage{
 				MessageType: models.Error,
-				Message:     "ERR7: Internal server error!",
-			}
-			protoMessage, err := proto.Marshal(response)
-			if err != nil {
-				log.Println("ERR5: ", err)
-				client.Conn.Close()
-				clients.Delete(client)
-				return
+				Reason:      canvas.Reason_INVALID_MESSAGE,
+				Message:     "Not a valid message!",
 			}
-			client.ServerChan <- protoMessage
+			send(client, nil, response)
 			client.Conn.Close()
 			clients.Delete(client)
 			return
@@ -186,198 +347,335 @@ func listen(client *models.Client) {
 		if !isValidMessage {
 			response := &canvas.ResponseMessage{
 				MessageType: models.Error,
+				Reason:      canvas.Reason_INVALID_MESSAGE,
 				Message:     "Not a valid message!",
 			}
-			protoMessage, err := proto.Marshal(response)
-			if err != nil {
-				log.Println("ERR6: ", err)
-				continue
-			}
-			client.ServerChan <- protoMessage
+			send(client, userMessage, response)
 			client.Conn.Close()
 			clients.Delete(client)
 			return
 		}
 		//#endregion Verify User message
 
-		if userMessage.GetMessageType() == models.GET_CONFIG {
+		//#region Rate limit
+		allowed, retryAfter, err := functions.TakeTokens(functions.MessageBuckets(client.IP, client.UserId, userMessage.GetMessageType()), 1, connections.RedisClient)
+		if err != nil {
+			log.Println("ERR28: ", err)
+		} else if !allowed {
 			response := &canvas.ResponseMessage{
-				MessageType:       models.Success,
-				CanvasWidth:       models.DEFAULT_X_SIZE,
-				CanvasHeight:      models.DEFAULT_Y_SIZE,
-				UserCooldown:      models.USER_COOLDOWN_PERIOD,
-				PixelCooldown:     models.PIXEL_COOLDOWN_PERIOD,
-				PingInterval:      models.PING_INTERVAL,
-				DisconnectTimeout: models.DISCONNECT_AFTER_SECS,
+				MessageType:  models.Throttled,
+				Reason:       canvas.Reason_THROTTLED,
+				Message:      "Too many requests!",
+				RetryAfterMs: retryAfter.Milliseconds(),
 			}
+			send(client, userMessage, response)
+			continue
+		}
+		//#endregion Rate limit
 
-			protoMessage, err := proto.Marshal(response)
-			if err != nil {
-				log.Println("ERR19: ", err)
-				continue
+		//#region Spectator access
+		readsCanvas := userMessage.GetMessageType() == models.GET_CONFIG || userMessage.GetMessageType() == models.GET_CANVAS
+		if client.Spectator && !readsCanvas && userMessage.GetMessageType() != models.JOIN_CANVAS {
+			response := &canvas.ResponseMessage{
+				MessageType: models.Error,
+				Reason:      canvas.Reason_SPECTATOR_READ_ONLY,
+				Message:     "Spectators can only view the canvas!",
 			}
-			client.ServerChan <- protoMessage
-		} else if userMessage.GetMessageType() == models.SET_CANVAS {
+			send(client, userMessage, response)
+			continue
+		}
+		//#endregion Spectator access
 
-			//#region verify placeTileMessage
-			isValid := functions.VerifyPlaceTileMessage(userMessage.GetMessageType(), userMessage.GetColor(), client.CanvasIdentifier)
-			if !isValid {
+		//#region Canvas
+		// requests act on the joined canvas, GET_CONFIG and GET_CANVAS can read any watched canvas instead
+		canvasIdentifier := client.Canvas()
+		if readsCanvas && userMessage.GetCanvasIdentifier() != "" {
+			if !client.Watches(userMessage.GetCanvasIdentifier()) {
 				response := &canvas.ResponseMessage{
 					MessageType: models.Error,
-					Message:     "Not a valid place tile request!",
-				}
-				protoMessage, err := proto.Marshal(response)
-				if err != nil {
-					log.Println("ERR7: ", err)
-					continue
+					Reason:      canvas.Reason_INVALID_CANVAS,
+					Message:     "Join the canvas first!",
 				}
-				client.ServerChan <- protoMessage
+				send(client, userMessage, response)
 				continue
 			}
-			//#endregion verify placeTileMessage
+			canvasIdentifier = userMessage.GetCanvasIdentifier()
+		}
+		//#endregion Canvas
+
+		if userMessage.GetMessageType() == models.GET_CONFIG {
+			// spectators have no user id and get no credits
+			response, credits := functions.ConfigResponse(canvasIdentifier, client.UserId, connections.RedisClient)
+			if credits != nil {
+				client.PixelsAvailable = credits.Balance
+			}
 
-			//#region canSet pixel
-			userCoolDown, message := functions.CheckUserCooldown(client.UserId, connections.RedisClient)
-			if userCoolDown {
+			send(client, userMessage, response)
+		} else if userMessage.GetMessageType() == models.JOIN_CANVAS {
+
+			//#region Join Canvas
+			// moves the connection to the canvas, or with Watch also sends it the updates of the canvas
+			canvasIdentifier = userMessage.GetCanvasIdentifier()
+			if !functions.CanvasExists(catalogue.CANVAS_LIST, canvasIdentifier) {
 				response := &canvas.ResponseMessage{
-					MessageType: models.UserCooldown,
-					Message:     message,
+					MessageType: models.Error,
+					Reason:      canvas.Reason_INVALID_CANVAS,
+					Message:     "Invalid Canvas Identifier",
 				}
-				protoMessage, err := proto.Marshal(response)
-				if err != nil {
-					log.Println("ERR8: ", err)
-					continue
+				send(client, userMessage, response)
+				continue
+			}
+			if !client.JoinCanvas(canvasIdentifier, userMessage.GetWatch()) {
+				response := &canvas.ResponseMessage{
+					MessageType: models.Error,
+					Reason:      canvas.Reason_TOO_MANY_CANVASES,
+					Message:     fmt.Sprintf("Cannot watch more than %v canvases!", models.MAX_WATCHED_CANVASES),
 				}
-				client.ServerChan <- protoMessage
+				send(client, userMessage, response)
 				continue
 			}
-			pixelCoolDown, message := functions.CheckPixelCooldown(userMessage.GetPixelId(), connections.RedisClient)
-			if pixelCoolDown {
+			//#endregion Join Canvas
+
+			//#region Send Config
+			response, credits := functions.ConfigResponse(canvasIdentifier, client.UserId, connections.RedisClient)
+			if credits != nil {
+				client.PixelsAvailable = credits.Balance
+			}
+			send(client, userMessage, response)
+			//#endregion Send Config
+
+		} else if userMessage.GetMessageType() == models.SET_CANVAS || userMessage.GetMessageType() == models.SET_PIXELS {
+
+			//#region Place pixels
+			// SET_CANVAS places a single pixel, SET_PIXELS a brush of them that is placed or refused as a whole
+			placer := models.Placer{
+				UserId:           client.UserId,
+				TeamClaim:        client.TeamClaim,
+				CanvasIdentifier: canvasIdentifier,
+				Origin:           models.EventOrigin{SessionId: client.SessionId, RequestId: userMessage.GetRequestId()},
+			}
+			result := functions.PlacePixels(userMessage, placer, connections.RedisClient, connections.MongoClient)
+			if result.Credits != nil {
+				sendCredits(client, *result.Credits)
+			} else if result.Response.GetReason() == canvas.Reason_NOT_ENOUGH_CREDITS {
+				// the refusal carries the balance the spend found
+				client.PixelsAvailable = uint16(result.Response.GetPixelsAvailable())
+			}
+			if result.Shadow != nil {
+				send(client, userMessage, result.Shadow)
+			}
+			send(client, userMessage, result.Response)
+			//#endregion Place pixels
+
+		} else if userMessage.GetMessageType() == models.UNDO_PLACEMENT {
+
+			//#region check lifecycle
+			phase := functions.CanvasLifecycle(canvasIdentifier)
+			resizing, err := functions.IsResizing(canvasIdentifier, connections.RedisClient)
+			if err != nil {
+				log.Println("ERR62: ", err)
+			}
+			if phase.State != models.CANVAS_OPEN || resizing {
 				response := &canvas.ResponseMessage{
-					MessageType: models.PixelCooldown,
-					Message:     message,
+					MessageType: models.CanvasNotOpen,
+					Reason:      canvas.Reason_CANVAS_NOT_OPEN,
+					Message:     fmt.Sprintf("Canvas is %v!", phase.State),
+					CanvasState: phase.State,
 				}
-				protoMessage, err := proto.Marshal(response)
-				if err != nil {
-					log.Println("ERR9: ", err)
-					continue
+				if resizing {
+					response.Reason = canvas.Reason_CANVAS_RESIZING
+					response.Message = "Canvas is being resized!"
 				}
-				client.ServerChan <- protoMessage
+				send(client, userMessage, response)
 				continue
 			}
-			//#endregion canSet pixel
+			//#endregion check lifecycle
 
-			//#region Set pixel
-			success, err := functions.SetPixelAndPublish(userMessage.GetPixelId(), userMessage.GetColor(), client.UserId, client.CanvasIdentifier, connections.RedisClient, connections.MongoClient)
-			if !success {
-				log.Println("ERR10: ", err)
+			//#region Undo placement
+			restored, err := functions.UndoPlacement(client.UserId, canvasIdentifier, models.EventOrigin{SessionId: client.SessionId, RequestId: userMessage.GetRequestId()}, connections.RedisClient, connections.MongoClient)
+			if err != nil || len(restored) == 0 {
 				response := &canvas.ResponseMessage{
 					MessageType: models.Error,
-					Message:     "Error setting pixel!",
+					Reason:      canvas.Reason_NOTHING_TO_UNDO,
+					Message:     "Nothing to undo!",
 				}
-				protoMessage, err := proto.Marshal(response)
-				if err != nil {
-					log.Println("ERR11: ", err)
-					continue
+				if err == functions.ErrCanvasResizing {
+					response.MessageType = models.CanvasNotOpen
+					response.Reason = canvas.Reason_CANVAS_RESIZING
+					response.Message = "Canvas is being resized!"
+				} else if err != nil {
+					log.Println("ERR64: ", err)
+					response.Reason = canvas.Reason_INTERNAL_ERROR
+					response.Message = "Error undoing placement!"
 				}
-				client.ServerChan <- protoMessage
+				send(client, userMessage, response)
 				continue
 			}
-			//#endregion Set pixel
+			//#endregion Undo placement
+
+			//#region Refund
+			if functions.UsesCredits(canvasIdentifier) {
+				err = functions.RefundCredits(client.UserId, functions.UndoCreditRefund(len(restored)), connections.RedisClient)
+				if err != nil {
+					log.Println("ERR66: ", err)
+				}
+				credits, err := functions.GetCredits(client.UserId, connections.RedisClient)
+				if err != nil {
+					log.Println("ERR67: ", err)
+				} else {
+					sendCredits(client, credits)
+				}
+			} else {
+				err = functions.RefundUserCooldown(client.UserId, canvasIdentifier, len(restored), connections.RedisClient)
+				if err != nil {
+					log.Println("ERR68: ", err)
+				}
+			}
+			team, err := functions.GetTeam(client.UserId, client.TeamClaim, connections.RedisClient)
+			if err != nil {
+				log.Println("ERR69: ", err)
+			}
+			err = functions.RecordTeamPlacement(canvasIdentifier, team, -len(restored), connections.RedisClient)
+			if err != nil {
+				log.Println("ERR70: ", err)
+			}
+			//#endregion Refund
 
 			//#region Send Response
 			response := &canvas.ResponseMessage{
-				MessageType: models.Success,
-				Message:     "Pixel set!",
+				MessageType:      models.Success,
+				Message:          "Placement undone!",
+				CanvasIdentifier: canvasIdentifier,
 			}
-			protoMessage, err := proto.Marshal(response)
-			if err != nil {
-				log.Println("ERR12: ", err)
-				continue
+			for _, pixel := range restored {
+				response.Pixels = append(response.Pixels, &canvas.Pixel{PixelId: pixel.PixelId, Color: pixel.PreviousColor, UserId: pixel.PreviousUserId})
 			}
-			client.ServerChan <- protoMessage
+			send(client, userMessage, response)
 			//#endregion Send Response
 
 		} else if userMessage.GetMessageType() == models.GET_CANVAS {
 
 			//#region Get Canvas
-			val, err := functions.GetCanvas(client.CanvasIdentifier, connections.RedisClient)
+			val, err := functions.GetCanvas(canvasIdentifier, connections.RedisClient)
 			if err != nil {
 				log.Println("ERR13: ", err)
 				response := &canvas.ResponseMessage{
 					MessageType: models.Error,
+					Reason:      canvas.Reason_INTERNAL_ERROR,
 					Message:     "Error getting canvas!",
 				}
-				protoMessage, err := proto.Marshal(response)
-				if err != nil {
-					log.Println("ERR14: ", err)
-					continue
-				}
-				client.ServerChan <- protoMessage
+				send(client, userMessage, response)
 				continue
 			}
 			//#endregion Get Canvas
 
 			//#region Send Canvas
 			response := &canvas.ResponseMessage{
-				MessageType: models.Success,
-				Canvas:      val,
+				MessageType:      models.Success,
+				Canvas:           val,
+				CanvasIdentifier: canvasIdentifier,
 			}
-			protoMessage, err := proto.Marshal(response)
-			if err != nil {
-				log.Println("ERR15: ", err)
-				continue
-			}
-			client.ServerChan <- protoMessage
+			send(client, userMessage, response)
 			//#endregion Send Canvas
 
 		} else if userMessage.GetMessageType() == models.VIEW_PIXEL {
 
 			//#region Get Pixel
-			pixelValue := functions.GetPixel(userMessage.GetPixelId(), client.CanvasIdentifier, connections.MongoClient)
+			pixelValue := functions.GetPixel(userMessage.GetPixelId(), canvasIdentifier, connections.MongoClient)
 			//#endregion Get Pixel
 
 			//#region Send Pixel
-			var protoMessage []byte
-			if pixelValue.UserId == "" {
+			// pixels nobody placed yet have no history
+			response := &canvas.ResponseMessage{
+				MessageType: models.Success,
+				UserId:      pixelValue.GetUserId(),
+				PixelId:     userMessage.GetPixelId(),
+				Color:       pixelValue.GetColor(),
+			}
+			if pixelValue.GetUserId() == "" {
+				response.Message = "Fill the pixel!"
+			}
+			send(client, userMessage, response)
+			//#endregion Send Pixel
+
+		} else if userMessage.GetMessageType() == models.GET_COOLDOWN {
+
+			//#region Get Cooldown
+			response, err := functions.GetCooldown(client.UserId, canvasIdentifier, userMessage.GetPixelId(), connections.RedisClient)
+			if err != nil {
+				log.Println("ERR43: ", err)
+				response = &canvas.ResponseMessage{
+					MessageType: models.Error,
+					Reason:      canvas.Reason_INTERNAL_ERROR,
+					Message:     "Error getting cooldown!",
+				}
+			}
+			//#endregion Get Cooldown
+
+			//#region Send Cooldown
+			send(client, userMessage, response)
+			//#endregion Send Cooldown
+
+		} else if userMessage.GetMessageType() == models.GET_LEADERBOARD {
+
+			//#region Get Leaderboard
+			window := userMessage.GetLeaderboardWindow()
+			if window == "" {
+				window = models.LEADERBOARD_ALL
+			}
+			if !functions.IsLeaderboardWindow(window) {
 				response := &canvas.ResponseMessage{
-					MessageType: models.Success,
-					Message:     "Fill the pixel!",
-					UserId:      pixelValue.UserId,
-					PixelId:     pixelValue.PixelId,
-					Color:       pixelValue.Color,
+					MessageType: models.Error,
+					Reason:      canvas.Reason_INVALID_LEADERBOARD_WINDOW,
+					Message:     fmt.Sprintf("Unknown leaderboard window %q!", window),
 				}
-				protoMessage, err = proto.Marshal(response)
-				if err != nil {
-					log.Println("ERR16: ", err)
+				send(client, userMessage, response)
+				continue
+			}
+			if userMessage.GetOffset() < 0 || userMessage.GetLimit() < 0 {
+				response := &canvas.ResponseMessage{
+					MessageType: models.Error,
+					Reason:      canvas.Reason_INVALID_MESSAGE,
+					Message:     "Offset and limit cannot be negative!",
+				}
+				send(client, userMessage, response)
+				continue
+			}
+			leaderboard, err := functions.GetLeaderboard(canvasIdentifier, window, int64(userMessage.GetOffset()), int64(userMessage.GetLimit()), connections.RedisClient)
+			var response *canvas.ResponseMessage
+			if err != nil {
+				log.Println("ERR58: ", err)
+				response = &canvas.ResponseMessage{
+					MessageType: models.Error,
+					Reason:      canvas.Reason_INTERNAL_ERROR,
+					Message:     "Error getting leaderboard!",
 				}
 			} else {
-				response := &canvas.ResponseMessage{
-					MessageType: models.Success,
-					UserId:      pixelValue.UserId,
-					PixelId:     pixelValue.PixelId,
-					Color:       pixelValue.Color,
+				response = &canvas.ResponseMessage{
+					MessageType:      models.Success,
+					LeaderboardTotal: leaderboard.Total,
 				}
-				protoMessage, err = proto.Marshal(response)
-				if err != nil {
-					log.Println("ERR17: ", err)
+				for _, entry := range leaderboard.Entries {
+					response.Leaderboard = append(response.Leaderboard, &canvas.LeaderboardEntry{
+						Rank:   entry.Rank,
+						UserId: entry.UserId,
+						Score:  entry.Score,
+					})
 				}
 			}
+			//#endregion Get Leaderboard
 
-			client.ServerChan <- protoMessage
-			//#endregion Send Pixel
+			//#region Send Leaderboard
+			send(client, userMessage, response)
+			//#endregion Send Leaderboard
 
 		} else {
 			response := &canvas.ResponseMessage{
 				MessageType: models.Error,
+				Reason:      canvas.Reason_INVALID_MESSAGE,
 				Message:     "Not a valid message!",
 			}
-			protoMessage, err := proto.Marshal(response)
-			if err != nil {
-				log.Println("ERR18: ", err)
-				continue
-			}
-			client.ServerChan <- protoMessage
+			send(client, userMessage, response)
 		}
 
 	}
@@ -392,24 +690,151 @@ func startPingPongChecker() {
 	}
 }
 
-// checkClients checks if the clients are still connected
+// checkClients checks if the clients are still connected and keeps their sessions alive
 func checkClients() {
+	liveSessions := map[string]string{}
 	clients.Range(func(key, value interface{}) bool {
 		client := key.(*models.Client)
 		if time.Since(client.LastPong) > models.DISCONNECT_AFTER_SECS*time.Second {
 			log.Println("Client is not responding, closing connection: ", client.UserId)
 			client.Conn.Close()
 			clients.Delete(client)
+		} else if !client.Spectator {
+			liveSessions[client.SessionId] = client.UserId
 		}
 		return true
 	})
+	err := functions.RefreshSessions(liveSessions, connections.RedisClient)
+	if err != nil {
+		log.Println("ERR34: ", err)
+	}
+}
+
+// startLifecycleWatcher notifies clients when the phase of their canvas changes
+func startLifecycleWatcher() {
+	ticker := time.NewTicker(models.LIFECYCLE_CHECK_INTERVAL * time.Second)
+	defer ticker.Stop()
+	for range ticker.C {
+		changed, err := functions.RefreshLifecycles(connections.RedisClient)
+		if err != nil {
+			log.Println("ERR46: ", err)
+		}
+		for _, canvasIdentifier := range changed {
+			phase := functions.CanvasLifecycle(canvasIdentifier)
+			log.Printf("Canvas %v is now %v\n", canvasIdentifier, phase.State)
+			response := &canvas.ResponseMessage{
+				MessageType:      models.CanvasState,
+				CanvasState:      phase.State,
+				StateStartsAt:    phase.StartsAt,
+				StateEndsAt:      phase.EndsAt,
+				CanvasIdentifier: canvasIdentifier,
+			}
+			clients.Range(func(key, value interface{}) bool {
+				client := key.(*models.Client)
+				if client.Watches(canvasIdentifier) {
+					send(client, nil, response)
+				}
+				return true
+			})
+		}
+	}
+}
+
+// sendCredits pushes the current pixel balance and the next refill time to the client
+func sendCredits(client *models.Client, credits models.CreditBalance) {
+	client.PixelsAvailable = credits.Balance
+	response := &canvas.ResponseMessage{
+		MessageType:     models.Credits,
+		PixelsAvailable: int32(credits.Balance),
+		NextPixelAt:     credits.NextRefillAt,
+	}
+	send(client, nil, response)
+}
+
+// send encodes a response in the protocol of the client. request is the request it answers and
+// whose RequestId is echoed, nil for events pushed by the server.
+func send(client *models.Client, request *canvas.RequestMessage, response *canvas.ResponseMessage) {
+	response.RequestId = request.GetRequestId()
+	message, err := functions.EncodeResponse(response, request, client.Protocol)
+	if err != nil {
+		log.Println("ERR42: ", err)
+		return
+	}
+	client.ServerChan <- message
+}
+
+// closeClient sends a close frame with the given reason before dropping the connection
+func closeClient(client *models.Client, code int, reason string) {
+	err := client.Conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(code, reason), time.Now().Add(time.Second))
+	if err != nil {
+		log.Println("ERR35: ", err)
+	}
+	client.Conn.Close()
+	clients.Delete(client)
+}
+
+// kickSessions closes the local connections of sessions pushed out by a newer session of the same user
+func kickSessions(kickSubChan <-chan *redis.Message, clients *sync.Map) {
+	for msg := range kickSubChan {
+		clients.Range(func(key, value interface{}) bool {
+			client := key.(*models.Client)
+			if client.SessionId == msg.Payload {
+				log.Printf("User %v connected elsewhere, closing session %v\n", client.UserId, client.SessionId)
+				closeClient(client, websocket.ClosePolicyViolation, "Connected from another session")
+				return false
+			}
+			return true
+		})
+	}
 }
 
+// broadcastCanvasEvents applies canvas wide changes published by any instance and forwards them to the clients of that canvas
+func broadcastCanvasEvents(canvasSubChan <-chan *redis.Message, clients *sync.Map) {
+	for msg := range canvasSubChan {
+		encoder, err := functions.NewEventEncoder([]byte(msg.Payload))
+		if err != nil {
+			log.Println("ERR51: ", err)
+			continue
+		}
+		event := encoder.Event()
+		if event.GetMessageType() == models.CanvasResized {
+			functions.SetCanvasSize(event.GetCanvasIdentifier(), event.GetCanvasWidth(), event.GetCanvasHeight())
+		}
+		clients.Range(func(key, value interface{}) bool {
+			client := key.(*models.Client)
+			if client.Watches(event.GetCanvasIdentifier()) {
+				forwardEvent(client, encoder)
+			}
+			return true
+		})
+	}
+}
+
+// forwardEvent hands a published event to the client in its protocol
+func forwardEvent(client *models.Client, encoder *functions.EventEncoder) {
+	message, err := encoder.Encode(client.Protocol, client.SessionId)
+	if err != nil {
+		log.Println("ERR47: ", err)
+		return
+	}
+	client.RedisChan <- message
+}
+
+// broadcastRedisMessages forwards pixel updates to the clients watching their canvas
 func broadcastRedisMessages(redisSubChan <-chan *redis.Message, clients *sync.Map) {
 	for msg := range redisSubChan {
+		encoder, err := functions.NewEventEncoder([]byte(msg.Payload))
+		if err != nil {
+			log.Println("ERR72: ", err)
+			continue
+		}
+		// updates published before they were tagged with their canvas still go to everyone
+		canvasIdentifier := encoder.Event().GetCanvasIdentifier()
 		clients.Range(func(key, value interface{}) bool {
 			client := key.(*models.Client)
-			client.RedisChan <- []byte(msg.Payload)
+			if canvasIdentifier == "" || client.Watches(canvasIdentifier) {
+				forwardEvent(client, encoder)
+			}
 			return true
 		})
 
@@ -0,0 +1,43 @@
+package models
+
+// CanvasInfo describes a canvas for the REST API
+type CanvasInfo struct {
+	Identifier string         `json:"identifier"`
+	Width      int32          `json:"width"`
+	Height     int32          `json:"height"`
+	UseCredits bool           `json:"useCredits"`
+	Palette    []PaletteColor `json:"palette"`
+	Regions    []Region       `json:"regions,omitempty"`
+	State      string         `json:"state"`
+	StartsAt   int64          `json:"startsAt,omitempty"`
+	EndsAt     int64          `json:"endsAt,omitempty"`
+}
+
+// CanvasRegion is a rectangle of pixels, row by row
+type CanvasRegion struct {
+	X      int32   `json:"x"`
+	Y      int32   `json:"y"`
+	Width  int32   `json:"width"`
+	Height int32   `json:"height"`
+	Pixels []int32 `json:"pixels"`
+}
+
+// PlacementRequest places one pixel, or a brush of them when PixelIds or Brush is set
+type PlacementRequest struct {
+	PixelId   int32   `json:"pixelId"`
+	Color     int32   `json:"color"`
+	PixelIds  []int32 `json:"pixelIds,omitempty"`
+	Brush     int32   `json:"brush,omitempty"`
+	ToPixelId int32   `json:"toPixelId,omitempty"`
+	BrushSize int32   `json:"brushSize,omitempty"`
+	RequestId string  `json:"requestId,omitempty"`
+}
+
+type PlacementResponse struct {
+	Message         string `json:"message,omitempty"`
+	Reason          string `json:"reason,omitempty"`
+	PixelId         int32  `json:"pixelId,omitempty"`
+	RetryAfterMs    int64  `json:"retryAfterMs,omitempty"`
+	PixelsAvailable int32  `json:"pixelsAvailable,omitempty"`
+	NextPixelAt     int64  `json:"nextPixelAt,omitempty"`
+}
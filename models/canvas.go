@@ -0,0 +1,38 @@
+package models
+
+type CanvasConfig struct {
+	Identifier string `json:"identifier"`
+	// UseCredits replaces the user cooldown with the pixel credit balance
+	UseCredits bool `json:"useCredits"`
+	// Mask names the placement mask of the canvas, loaded from models.MASK_DIR or mongo
+	Mask string `json:"mask,omitempty"`
+	// Palette lists the colours of the canvas in order, colour 1 is the first entry. Empty uses the default palette.
+	Palette []PaletteColor `json:"palette,omitempty"`
+	// Regions restrict parts of the canvas to some teams
+	Regions []Region `json:"regions,omitempty"`
+}
+
+type PaletteColor struct {
+	Hex  string `json:"hex"`
+	Name string `json:"name"`
+}
+
+// Region is a rectangle of the canvas that only Teams may paint
+type Region struct {
+	Name   string   `json:"name"`
+	X      int32    `json:"x"`
+	Y      int32    `json:"y"`
+	Width  int32    `json:"width"`
+	Height int32    `json:"height"`
+	Teams  []string `json:"teams"`
+}
+
+type ResizeRequest struct {
+	Width  int32 `json:"width"`
+	Height int32 `json:"height"`
+}
+
+type TeamRequest struct {
+	UserId string `json:"userId"`
+	Team   string `json:"team"`
+}
@@ -0,0 +1,165 @@
+package models
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// #region Config
+
+// Settings below are read from the environment at startup and fall back to the given defaults.
+
+// #region Auth
+// HMAC secret user tokens are signed with, the server refuses to start without it
+var JWT_SECRET = envString("JWT_SECRET", "")
+
+// #endregion Auth
+
+// #region Handshake
+var (
+	// Origins allowed to open a websocket, e.g. "https://canvas.example.com,https://*.example.com".
+	// Empty only allows same-host origins, "*" allows every origin.
+	ALLOWED_ORIGINS             = envList("ALLOWED_ORIGINS", nil)
+	MAX_MESSAGE_SIZE            = envInt("MAX_MESSAGE_SIZE", 4096)
+	HANDSHAKE_TIMEOUT           = envSeconds("HANDSHAKE_TIMEOUT_SECS", 10)
+	READ_HEADER_TIMEOUT         = envSeconds("READ_HEADER_TIMEOUT_SECS", 5)
+	REJECT_UNKNOWN_SUBPROTOCOLS = envBool("REJECT_UNKNOWN_SUBPROTOCOLS", false)
+	// Read the client address from X-Forwarded-For instead of the socket when running behind a proxy
+	TRUST_PROXY_HEADERS = envBool("TRUST_PROXY_HEADERS", false)
+)
+
+// #endregion Handshake
+
+// #region Spectators
+// Allow connections without a user id and token to watch canvases read-only
+var ALLOW_SPECTATORS = envBool("ALLOW_SPECTATORS", true)
+
+// #endregion Spectators
+
+// #region Sessions
+var (
+	MAX_SESSIONS_PER_USER = envInt("MAX_SESSIONS_PER_USER", 1)
+	// SESSION_POLICY_REJECT refuses new connections over the limit, SESSION_POLICY_KICK_OLDEST closes the oldest ones
+	SESSION_LIMIT_POLICY = envString("SESSION_LIMIT_POLICY", SESSION_POLICY_KICK_OLDEST)
+)
+
+// #endregion Sessions
+
+// #region Canvases
+var (
+	// Canvases a connection can watch at once with JOIN_CANVAS
+	MAX_WATCHED_CANVASES = envInt("MAX_WATCHED_CANVASES", 16)
+	// JSON list of canvas configs, the built-in canvases are used when the file does not exist
+	CANVAS_CONFIG_FILE = envString("CANVAS_CONFIG_FILE", "canvases.json")
+	// Directory of placement masks, a canvas with mask "NAME" uses NAME.rle or NAME.png
+	MASK_DIR = envString("MASK_DIR", "masks")
+	// Largest width x height a single region request can read
+	MAX_REGION_PIXELS = envInt("MAX_REGION_PIXELS", 250000)
+)
+
+// #endregion Canvases
+
+// #region Batches
+// Largest number of pixels a single SET_PIXELS request can place
+var MAX_BATCH_PIXELS = envInt("MAX_BATCH_PIXELS", 16)
+
+// #endregion Batches
+
+// #region Undo
+var (
+	// Seconds a user has to undo their most recent placement
+	UNDO_GRACE_SECS = envInt("UNDO_GRACE_SECS", 5)
+	// Percentage of the cooldown or credits given back by an undo
+	UNDO_REFUND_PERCENT = envInt("UNDO_REFUND_PERCENT", 100)
+)
+
+// #endregion Undo
+
+// #region Event Stream
+var (
+	// Updates kept per canvas for SSE clients resuming with Last-Event-ID
+	UPDATE_STREAM_LENGTH = envInt("UPDATE_STREAM_LENGTH", 10000)
+	// Updates queued for a slow SSE client before it is disconnected and has to resume
+	STREAM_CLIENT_BUFFER = envInt("STREAM_CLIENT_BUFFER", 256)
+)
+
+// #endregion Event Stream
+
+// #region gRPC
+// Address the gRPC CanvasService listens on, e.g. ":9090". Off by default, set it to serve gRPC
+var GRPC_ADDR = envString("GRPC_ADDR", "")
+
+// #endregion gRPC
+
+// #region Credits
+// Canvases with UseCredits give every user up to CREDIT_CAP pixels, one more every CREDIT_REFILL_SECS
+var (
+	CREDIT_CAP         = envInt("CREDIT_CAP", 10)
+	CREDIT_REFILL_SECS = envInt("CREDIT_REFILL_SECS", 30)
+)
+
+// #endregion Credits
+
+// #region Quota
+// An external quota provider overrides CREDIT_CAP per user, e.g. for paying users
+var (
+	QUOTA_PROVIDER         = envString("QUOTA_PROVIDER", "")
+	QUOTA_URL              = envString("QUOTA_URL", "http://localhost:8081/getPixelsAvailable/{userId}")
+	QUOTA_FIELD            = envString("QUOTA_FIELD", "pixelsAvailable")
+	QUOTA_TIMEOUT          = time.Duration(envInt("QUOTA_TIMEOUT_MS", 500)) * time.Millisecond
+	QUOTA_RETRIES          = envInt("QUOTA_RETRIES", 2)
+	QUOTA_RETRY_BACKOFF    = time.Duration(envInt("QUOTA_RETRY_BACKOFF_MS", 100)) * time.Millisecond
+	QUOTA_CACHE_TTL        = envSeconds("QUOTA_CACHE_SECS", 60)
+	QUOTA_LAST_KNOWN_TTL   = envSeconds("QUOTA_LAST_KNOWN_SECS", 3600)
+	QUOTA_FAILURE_MODE     = envString("QUOTA_FAILURE_MODE", QUOTA_LAST_KNOWN)
+	QUOTA_FAIL_OPEN_PIXELS = envInt("QUOTA_FAIL_OPEN_PIXELS", CREDIT_CAP)
+)
+
+// #endregion Quota
+
+func envString(key string, fallback string) string {
+	value, ok := os.LookupEnv(key)
+	if !ok || value == "" {
+		return fallback
+	}
+	return value
+}
+
+func envList(key string, fallback []string) []string {
+	value := envString(key, "")
+	if value == "" {
+		return fallback
+	}
+	var list []string
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+func envInt(key string, fallback int) int {
+	value, err := strconv.Atoi(envString(key, ""))
+	if err != nil {
+		return fallback
+	}
+	return value
+}
+
+func envBool(key string, fallback bool) bool {
+	value, err := strconv.ParseBool(envString(key, ""))
+	if err != nil {
+		return fallback
+	}
+	return value
+}
+
+func envSeconds(key string, fallback int) time.Duration {
+	return time.Duration(envInt(key, fallback)) * time.Second
+}
+
+// #endregion Config
@@ -0,0 +1,20 @@
+package models
+
+// Quota Providers
+const (
+	QUOTA_PROVIDER_HTTP = "http"
+	QUOTA_PROVIDER_STUB = "stub"
+)
+
+// Quota Failure Modes
+const (
+	QUOTA_FAIL_OPEN   = "fail_open"
+	QUOTA_FAIL_CLOSED = "fail_closed"
+	QUOTA_LAST_KNOWN  = "last_known"
+)
+
+type CreditBalance struct {
+	Spent        bool
+	Balance      uint16
+	NextRefillAt int64 // unix ms, 0 when the balance is full
+}
@@ -0,0 +1,30 @@
+package models
+
+// Leaderboard Windows
+const (
+	LEADERBOARD_ALL     = "all"
+	LEADERBOARD_DAILY   = "daily"
+	LEADERBOARD_EVENT   = "event"
+	LEADERBOARD_SHOWING = "showing"
+)
+
+var LEADERBOARD_WINDOWS = []string{LEADERBOARD_ALL, LEADERBOARD_DAILY, LEADERBOARD_EVENT, LEADERBOARD_SHOWING}
+
+const (
+	LEADERBOARD_DEFAULT_LIMIT = 10
+	LEADERBOARD_MAX_LIMIT     = 100
+	LEADERBOARD_DAILY_DAYS    = 7
+)
+
+type LeaderboardEntry struct {
+	Rank   int64  `json:"rank"`
+	UserId string `json:"userId"`
+	Score  int64  `json:"score"`
+}
+
+type Leaderboard struct {
+	Window  string             `json:"window"`
+	Total   int64              `json:"total"`
+	Offset  int64              `json:"offset"`
+	Entries []LeaderboardEntry `json:"entries"`
+}
@@ -0,0 +1,21 @@
+package models
+
+// Canvas States
+const (
+	CANVAS_SCHEDULED = "scheduled"
+	CANVAS_OPEN      = "open"
+	CANVAS_PAUSED    = "paused"
+	CANVAS_CLOSED    = "closed"
+	CANVAS_ARCHIVED  = "archived"
+)
+
+var CANVAS_STATES = []string{CANVAS_SCHEDULED, CANVAS_OPEN, CANVAS_PAUSED, CANVAS_CLOSED, CANVAS_ARCHIVED}
+
+const LIFECYCLE_CHECK_INTERVAL = 1
+
+// LifecyclePhase is one window of a canvas schedule, times are unix seconds and an EndsAt of 0 never ends
+type LifecyclePhase struct {
+	State    string `json:"state"`
+	StartsAt int64  `json:"startsAt"`
+	EndsAt   int64  `json:"endsAt,omitempty"`
+}
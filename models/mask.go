@@ -0,0 +1,38 @@
+package models
+
+// Mask marks the cells of a canvas that can be painted, Cells is row-major and true when placeable
+type Mask struct {
+	Width  int32
+	Height int32
+	Cells  []bool
+}
+
+func NewMask(width int32, height int32) *Mask {
+	return &Mask{Width: width, Height: height, Cells: make([]bool, width*height)}
+}
+
+// CanPlace reports whether a cell is placeable, cells outside of the mask never are
+func (m *Mask) CanPlace(x int32, y int32) bool {
+	if x < 0 || y < 0 || x >= m.Width || y >= m.Height {
+		return false
+	}
+	return m.Cells[y*m.Width+x]
+}
+
+func (m *Mask) PlaceableCount() int {
+	count := 0
+	for _, placeable := range m.Cells {
+		if placeable {
+			count++
+		}
+	}
+	return count
+}
+
+// MaskDocument is a mask stored in mongo, RLE holds the runs of the RLE format without the size
+type MaskDocument struct {
+	Name   string `bson:"name"`
+	Width  int32  `bson:"width"`
+	Height int32  `bson:"height"`
+	RLE    string `bson:"rle"`
+}
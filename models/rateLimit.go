@@ -0,0 +1,44 @@
+package models
+
+// RateLimit describes a token bucket: Capacity is the burst size and
+// RefillPerSec the number of tokens added back every second.
+type RateLimit struct {
+	Capacity     int
+	RefillPerSec float64
+}
+
+// #region Upgrade Limits
+var (
+	IP_UPGRADE_LIMIT   = RateLimit{Capacity: 30, RefillPerSec: 0.5}
+	USER_UPGRADE_LIMIT = RateLimit{Capacity: 10, RefillPerSec: 0.2}
+)
+
+// #endregion Upgrade Limits
+
+// #region Message Limits
+var USER_MESSAGE_LIMITS = map[int32]RateLimit{
+	GET_CONFIG:      {Capacity: 10, RefillPerSec: 1},
+	GET_CANVAS:      {Capacity: 5, RefillPerSec: 0.2},
+	SET_CANVAS:      {Capacity: 10, RefillPerSec: 1},
+	VIEW_PIXEL:      {Capacity: 30, RefillPerSec: 5},
+	GET_COOLDOWN:    {Capacity: 10, RefillPerSec: 1},
+	GET_LEADERBOARD: {Capacity: 5, RefillPerSec: 0.5},
+	SET_PIXELS:      {Capacity: 5, RefillPerSec: 0.5},
+	UNDO_PLACEMENT:  {Capacity: 5, RefillPerSec: 0.5},
+	JOIN_CANVAS:     {Capacity: 10, RefillPerSec: 0.5},
+}
+
+// IP limits are looser than user limits since many users can share an address
+var IP_MESSAGE_LIMITS = map[int32]RateLimit{
+	GET_CONFIG:      {Capacity: 50, RefillPerSec: 5},
+	GET_CANVAS:      {Capacity: 25, RefillPerSec: 1},
+	SET_CANVAS:      {Capacity: 50, RefillPerSec: 5},
+	VIEW_PIXEL:      {Capacity: 150, RefillPerSec: 25},
+	GET_COOLDOWN:    {Capacity: 50, RefillPerSec: 5},
+	GET_LEADERBOARD: {Capacity: 25, RefillPerSec: 2.5},
+	SET_PIXELS:      {Capacity: 25, RefillPerSec: 2.5},
+	UNDO_PLACEMENT:  {Capacity: 25, RefillPerSec: 2.5},
+	JOIN_CANVAS:     {Capacity: 50, RefillPerSec: 2.5},
+}
+
+// #endregion Message Limits
@@ -2,16 +2,63 @@ package models
 
 // Message Types
 const (
-	GET_CONFIG = 0
-	GET_CANVAS = 1
-	SET_CANVAS = 2
-	VIEW_PIXEL = 3
-	DISCONNET  = 4
-	TEST       = 5
+	GET_CONFIG      = 0
+	GET_CANVAS      = 1
+	SET_CANVAS      = 2
+	VIEW_PIXEL      = 3
+	GET_COOLDOWN    = 6
+	GET_LEADERBOARD = 7
+	SET_PIXELS      = 8
+	UNDO_PLACEMENT  = 9
+	JOIN_CANVAS     = 10
 )
 
-type UserMessage struct {
-	MessageType int32 `json:"messageType"`
-	PixelId     int32 `json:"pixelId"`
-	Color       int32 `json:"color"`
+// Message types accepted from clients
+var MESSAGE_TYPES = []int32{GET_CONFIG, GET_CANVAS, SET_CANVAS, VIEW_PIXEL, GET_COOLDOWN, GET_LEADERBOARD, SET_PIXELS, UNDO_PLACEMENT, JOIN_CANVAS}
+
+// Brushes of a SET_PIXELS request
+const (
+	BRUSH_LIST   = 0 // the pixels in PixelIds
+	BRUSH_LINE   = 1 // a line from PixelId to ToPixelId
+	BRUSH_SQUARE = 2 // a BrushSize x BrushSize square with PixelId as its top left corner
+)
+
+// Protocol Versions
+const (
+	// Flat RequestMessage and ResponseMessage
+	PROTOCOL_V1 = 1
+	// ClientEnvelope and ServerEnvelope with typed payloads
+	PROTOCOL_V2 = 2
+)
+
+// Wire Formats
+const (
+	// Binary frames of protobuf messages
+	FORMAT_PROTOBUF = "proto"
+	// Text frames of the same messages as protojson
+	FORMAT_JSON = "json"
+)
+
+// Protocol is the version and wire format of a connection, negotiated at handshake
+type Protocol struct {
+	Version int32
+	Format  string
+}
+
+// Websocket Subprotocols
+const (
+	SUBPROTOCOL_PROTOBUF    = "canvas.proto"
+	SUBPROTOCOL_PROTOBUF_V2 = "canvas.v2.proto"
+	SUBPROTOCOL_JSON        = "canvas.json"
+	SUBPROTOCOL_JSON_V2     = "canvas.v2.json"
+)
+
+// Preferred first, clients offering several get the newest version and protobuf over JSON
+var SUBPROTOCOLS = []string{SUBPROTOCOL_PROTOBUF_V2, SUBPROTOCOL_JSON_V2, SUBPROTOCOL_PROTOBUF, SUBPROTOCOL_JSON}
+
+var SUBPROTOCOL_PROTOCOLS = map[string]Protocol{
+	SUBPROTOCOL_PROTOBUF:    {Version: PROTOCOL_V1, Format: FORMAT_PROTOBUF},
+	SUBPROTOCOL_PROTOBUF_V2: {Version: PROTOCOL_V2, Format: FORMAT_PROTOBUF},
+	SUBPROTOCOL_JSON:        {Version: PROTOCOL_V1, Format: FORMAT_JSON},
+	SUBPROTOCOL_JSON_V2:     {Version: PROTOCOL_V2, Format: FORMAT_JSON},
 }
@@ -7,4 +7,10 @@ const (
 	PixelCooldown = 3
 	Update        = 4
 	Error         = 5
+	Throttled     = 6
+	Credits       = 7
+	CanvasState   = 8
+	CanvasNotOpen = 9
+	CanvasResized = 10
+	UpdateBatch   = 11
 )
@@ -0,0 +1,32 @@
+package models
+
+// Sanction Types
+const (
+	SANCTION_BAN    = "ban"
+	SANCTION_MUTE   = "mute"
+	SANCTION_SHADOW = "shadow"
+)
+
+var SANCTION_TYPES = []string{SANCTION_BAN, SANCTION_MUTE, SANCTION_SHADOW}
+
+type Sanction struct {
+	UserId    string `json:"userId"`
+	Type      string `json:"type"`
+	Reason    string `json:"reason,omitempty"`
+	IssuedBy  string `json:"issuedBy,omitempty"`
+	IssuedAt  int64  `json:"issuedAt"`
+	ExpiresAt int64  `json:"expiresAt,omitempty"`
+}
+
+type SanctionRequest struct {
+	UserId       string `json:"userId"`
+	Type         string `json:"type"`
+	Reason       string `json:"reason"`
+	DurationSecs int64  `json:"durationSecs"`
+}
+
+type UserSanctions struct {
+	Ban    *Sanction `json:"ban,omitempty"`
+	Mute   *Sanction `json:"mute,omitempty"`
+	Shadow *Sanction `json:"shadow,omitempty"`
+}
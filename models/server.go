@@ -2,6 +2,7 @@ package models
 
 import (
 	"log"
+	"sync"
 	"time"
 
 	"github.com/gorilla/websocket"
@@ -16,6 +17,24 @@ const (
 const (
 	DISCONNECT_AFTER_SECS = 30
 	PING_INTERVAL         = 5
+	SESSION_TTL_SECS      = DISCONNECT_AFTER_SECS * 2
+)
+
+// JWT claim holding the team of a user
+const TEAM_CLAIM = "team"
+
+// Session Limit Policies
+const (
+	SESSION_POLICY_REJECT      = "reject"
+	SESSION_POLICY_KICK_OLDEST = "kick_oldest"
+)
+
+// User Roles
+const (
+	ROLE_MODERATOR = "moderator"
+	ROLE_ADMIN     = "admin"
+	// Trusted services allowed to place pixels over the REST API
+	ROLE_INTEGRATION = "integration"
 )
 
 // #endregion User
@@ -23,23 +42,87 @@ const (
 // #region Canvas
 
 const (
-	DEFAULT_X_SIZE    = 200
-	DEFAULT_Y_SIZE    = 200
-	CAN_PLACE_TILE    = 0
-	CANNOT_PLACE_TILE = -1
+	DEFAULT_X_SIZE  = 200
+	DEFAULT_Y_SIZE  = 200
+	MAX_CANVAS_SIZE = 2000
+	// Colours are stored as i8 and 0 is an empty pixel
+	MAX_PALETTE_SIZE = 127
+	EMPTY_PIXEL_HEX  = "#FFFFFF"
 )
 
 // #endregion Canvas
 
 // #region Client
-type Client struct {
-	Conn             *websocket.Conn
-	ServerChan       chan []byte
-	RedisChan        chan []byte
-	LastPong         time.Time
-	UserId           string
+
+// StreamEvent is an update read from the update stream of a canvas, Id is its sequence number
+type StreamEvent struct {
+	Id      string
+	Payload []byte
+}
+
+// StreamClient is a read-only SSE client following the updates of one canvas
+type StreamClient struct {
 	CanvasIdentifier string
-	PixelsAvailable  uint16
+	Protocol         Protocol
+	Events           chan StreamEvent
+}
+type Client struct {
+	Conn            *websocket.Conn
+	ServerChan      chan []byte
+	RedisChan       chan []byte
+	LastPong        time.Time
+	UserId          string
+	IP              string
+	Spectator       bool
+	SessionId       string
+	TeamClaim       string
+	PixelsAvailable uint16
+	Protocol        Protocol
+
+	// canvasIdentifier is the joined canvas requests act on, watching the canvases the client gets updates of.
+	// Both change with JOIN_CANVAS while events are broadcast, so they are only read through the methods below.
+	canvasLock       sync.RWMutex
+	canvasIdentifier string
+	watching         map[string]bool
+}
+
+// Canvas returns the joined canvas
+func (c *Client) Canvas() string {
+	c.canvasLock.RLock()
+	defer c.canvasLock.RUnlock()
+	return c.canvasIdentifier
+}
+
+// Watches tells if the client gets the updates of a canvas
+func (c *Client) Watches(canvasIdentifier string) bool {
+	c.canvasLock.RLock()
+	defer c.canvasLock.RUnlock()
+	return c.watching[canvasIdentifier]
+}
+
+// JoinCanvas moves the client to a canvas, or with watch only adds it to the watched canvases.
+// It returns false when that would watch more than MAX_WATCHED_CANVASES.
+func (c *Client) JoinCanvas(canvasIdentifier string, watch bool) bool {
+	c.canvasLock.Lock()
+	defer c.canvasLock.Unlock()
+	if !watch || c.watching == nil {
+		c.canvasIdentifier = canvasIdentifier
+		c.watching = map[string]bool{canvasIdentifier: true}
+		return true
+	}
+	if !c.watching[canvasIdentifier] && len(c.watching) >= MAX_WATCHED_CANVASES {
+		return false
+	}
+	c.watching[canvasIdentifier] = true
+	return true
+}
+
+// frameType sends JSON as text frames and protobuf as binary frames
+func (c *Client) frameType() int {
+	if c.Protocol.Format == FORMAT_JSON {
+		return websocket.TextMessage
+	}
+	return websocket.BinaryMessage
 }
 
 func (c *Client) WriteEvents() {
@@ -50,7 +133,7 @@ func (c *Client) WriteEvents() {
 				c.Conn.WriteMessage(websocket.CloseMessage, []byte{})
 				return
 			}
-			err := c.Conn.WriteMessage(websocket.BinaryMessage, message)
+			err := c.Conn.WriteMessage(c.frameType(), message)
 			if err != nil {
 				log.Println("server chan: error writing to websocket:", err)
 			}
@@ -60,7 +143,7 @@ func (c *Client) WriteEvents() {
 				return
 			}
 
-			err := c.Conn.WriteMessage(websocket.BinaryMessage, message)
+			err := c.Conn.WriteMessage(c.frameType(), message)
 			if err != nil {
 				log.Println("server redis: error writing to websocket:", err)
 			}
@@ -70,6 +153,25 @@ func (c *Client) WriteEvents() {
 
 // #endregion Client
 
+type ConnectionStats struct {
+	Players    int `json:"players"`
+	Spectators int `json:"spectators"`
+}
+
+// EventOrigin is the session and request an event is published for, so the placer can match the update to its request
+type EventOrigin struct {
+	SessionId string
+	RequestId string
+}
+
+// Placer is who places pixels and where
+type Placer struct {
+	UserId           string
+	TeamClaim        string
+	CanvasIdentifier string
+	Origin           EventOrigin
+}
+
 type PixelData struct {
 	UserId    string `json:"userId,omitempty" bson:"userId"`
 	PixelId   int32  `json:"pixelId,omitempty" bson:"pixelId"`
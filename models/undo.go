@@ -0,0 +1,16 @@
+package models
+
+// UndoPixel is a placed pixel together with what it covered
+type UndoPixel struct {
+	PixelId           int32  `json:"pixelId"`
+	Color             int32  `json:"color"`
+	PreviousColor     int32  `json:"previousColor"`
+	PreviousUserId    string `json:"previousUserId,omitempty"`
+	PreviousTimeStamp int64  `json:"previousTimeStamp,omitempty"`
+}
+
+// UndoRecord is the most recent placement of a user, kept for UNDO_GRACE_SECS
+type UndoRecord struct {
+	Pixels   []UndoPixel `json:"pixels"`
+	PlacedAt int64       `json:"placedAt"`
+}
@@ -20,14 +20,125 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// Reason tells clients why a request was refused, Message only carries optional human text
+type Reason int32
+
+const (
+	Reason_NONE                       Reason = 0
+	Reason_INTERNAL_ERROR             Reason = 1
+	Reason_INVALID_MESSAGE            Reason = 2
+	Reason_THROTTLED                  Reason = 3
+	Reason_SPECTATOR_READ_ONLY        Reason = 4
+	Reason_INVALID_BRUSH              Reason = 5
+	Reason_INVALID_PIXEL              Reason = 6
+	Reason_CANVAS_NOT_OPEN            Reason = 7
+	Reason_CANVAS_RESIZING            Reason = 8
+	Reason_BANNED                     Reason = 9
+	Reason_MUTED                      Reason = 10
+	Reason_TEAM_REGION                Reason = 11
+	Reason_USER_COOLDOWN              Reason = 12
+	Reason_PIXEL_COOLDOWN             Reason = 13
+	Reason_NOT_ENOUGH_CREDITS         Reason = 14
+	Reason_NOTHING_TO_UNDO            Reason = 15
+	Reason_INVALID_LEADERBOARD_WINDOW Reason = 16
+	Reason_INVALID_CANVAS             Reason = 17
+	Reason_TOO_MANY_CANVASES          Reason = 18
+)
+
+// Enum value maps for Reason.
+var (
+	Reason_name = map[int32]string{
+		0:  "NONE",
+		1:  "INTERNAL_ERROR",
+		2:  "INVALID_MESSAGE",
+		3:  "THROTTLED",
+		4:  "SPECTATOR_READ_ONLY",
+		5:  "INVALID_BRUSH",
+		6:  "INVALID_PIXEL",
+		7:  "CANVAS_NOT_OPEN",
+		8:  "CANVAS_RESIZING",
+		9:  "BANNED",
+		10: "MUTED",
+		11: "TEAM_REGION",
+		12: "USER_COOLDOWN",
+		13: "PIXEL_COOLDOWN",
+		14: "NOT_ENOUGH_CREDITS",
+		15: "NOTHING_TO_UNDO",
+		16: "INVALID_LEADERBOARD_WINDOW",
+		17: "INVALID_CANVAS",
+		18: "TOO_MANY_CANVASES",
+	}
+	Reason_value = map[string]int32{
+		"NONE":                       0,
+		"INTERNAL_ERROR":             1,
+		"INVALID_MESSAGE":            2,
+		"THROTTLED":                  3,
+		"SPECTATOR_READ_ONLY":        4,
+		"INVALID_BRUSH":              5,
+		"INVALID_PIXEL":              6,
+		"CANVAS_NOT_OPEN":            7,
+		"CANVAS_RESIZING":            8,
+		"BANNED":                     9,
+		"MUTED":                      10,
+		"TEAM_REGION":                11,
+		"USER_COOLDOWN":              12,
+		"PIXEL_COOLDOWN":             13,
+		"NOT_ENOUGH_CREDITS":         14,
+		"NOTHING_TO_UNDO":            15,
+		"INVALID_LEADERBOARD_WINDOW": 16,
+		"INVALID_CANVAS":             17,
+		"TOO_MANY_CANVASES":          18,
+	}
+)
+
+func (x Reason) Enum() *Reason {
+	p := new(Reason)
+	*p = x
+	return p
+}
+
+func (x Reason) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Reason) Descriptor() protoreflect.EnumDescriptor {
+	return file_definitions_proto_enumTypes[0].Descriptor()
+}
+
+func (Reason) Type() protoreflect.EnumType {
+	return &file_definitions_proto_enumTypes[0]
+}
+
+func (x Reason) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Reason.Descriptor instead.
+func (Reason) EnumDescriptor() ([]byte, []int) {
+	return file_definitions_proto_rawDescGZIP(), []int{0}
+}
+
 type RequestMessage struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	MessageType int32 `protobuf:"varint,1,opt,name=MessageType,proto3" json:"MessageType,omitempty"`
-	PixelId     int32 `protobuf:"varint,2,opt,name=PixelId,proto3" json:"PixelId,omitempty"`
-	Color       int32 `protobuf:"varint,3,opt,name=Color,proto3" json:"Color,omitempty"`
+	MessageType       int32   `protobuf:"varint,1,opt,name=MessageType,proto3" json:"MessageType,omitempty"`
+	PixelId           int32   `protobuf:"varint,2,opt,name=PixelId,proto3" json:"PixelId,omitempty"`
+	Color             int32   `protobuf:"varint,3,opt,name=Color,proto3" json:"Color,omitempty"`
+	LeaderboardWindow string  `protobuf:"bytes,4,opt,name=LeaderboardWindow,proto3" json:"LeaderboardWindow,omitempty"`
+	Offset            int32   `protobuf:"varint,5,opt,name=Offset,proto3" json:"Offset,omitempty"`
+	Limit             int32   `protobuf:"varint,6,opt,name=Limit,proto3" json:"Limit,omitempty"`
+	PixelIds          []int32 `protobuf:"varint,7,rep,packed,name=PixelIds,proto3" json:"PixelIds,omitempty"`
+	Brush             int32   `protobuf:"varint,8,opt,name=Brush,proto3" json:"Brush,omitempty"`
+	ToPixelId         int32   `protobuf:"varint,9,opt,name=ToPixelId,proto3" json:"ToPixelId,omitempty"`
+	BrushSize         int32   `protobuf:"varint,10,opt,name=BrushSize,proto3" json:"BrushSize,omitempty"`
+	// Echoed on the replies to this request
+	RequestId string `protobuf:"bytes,11,opt,name=RequestId,proto3" json:"RequestId,omitempty"`
+	// Canvas to join with JOIN_CANVAS, or the watched canvas GET_CONFIG and GET_CANVAS read instead of the joined one
+	CanvasIdentifier string `protobuf:"bytes,12,opt,name=CanvasIdentifier,proto3" json:"CanvasIdentifier,omitempty"`
+	// JOIN_CANVAS watches the canvas next to the ones already watched instead of moving to it
+	Watch bool `protobuf:"varint,13,opt,name=Watch,proto3" json:"Watch,omitempty"`
 }
 
 func (x *RequestMessage) Reset() {
@@ -83,30 +194,307 @@ func (x *RequestMessage) GetColor() int32 {
 	return 0
 }
 
+func (x *RequestMessage) GetLeaderboardWindow() string {
+	if x != nil {
+		return x.LeaderboardWindow
+	}
+	return ""
+}
+
+func (x *RequestMessage) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *RequestMessage) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *RequestMessage) GetPixelIds() []int32 {
+	if x != nil {
+		return x.PixelIds
+	}
+	return nil
+}
+
+func (x *RequestMessage) GetBrush() int32 {
+	if x != nil {
+		return x.Brush
+	}
+	return 0
+}
+
+func (x *RequestMessage) GetToPixelId() int32 {
+	if x != nil {
+		return x.ToPixelId
+	}
+	return 0
+}
+
+func (x *RequestMessage) GetBrushSize() int32 {
+	if x != nil {
+		return x.BrushSize
+	}
+	return 0
+}
+
+func (x *RequestMessage) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+func (x *RequestMessage) GetCanvasIdentifier() string {
+	if x != nil {
+		return x.CanvasIdentifier
+	}
+	return ""
+}
+
+func (x *RequestMessage) GetWatch() bool {
+	if x != nil {
+		return x.Watch
+	}
+	return false
+}
+
+type Pixel struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PixelId int32  `protobuf:"varint,1,opt,name=PixelId,proto3" json:"PixelId,omitempty"`
+	Color   int32  `protobuf:"varint,2,opt,name=Color,proto3" json:"Color,omitempty"`
+	UserId  string `protobuf:"bytes,3,opt,name=UserId,proto3" json:"UserId,omitempty"`
+}
+
+func (x *Pixel) Reset() {
+	*x = Pixel{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_definitions_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Pixel) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Pixel) ProtoMessage() {}
+
+func (x *Pixel) ProtoReflect() protoreflect.Message {
+	mi := &file_definitions_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Pixel.ProtoReflect.Descriptor instead.
+func (*Pixel) Descriptor() ([]byte, []int) {
+	return file_definitions_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Pixel) GetPixelId() int32 {
+	if x != nil {
+		return x.PixelId
+	}
+	return 0
+}
+
+func (x *Pixel) GetColor() int32 {
+	if x != nil {
+		return x.Color
+	}
+	return 0
+}
+
+func (x *Pixel) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type LeaderboardEntry struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Rank   int64  `protobuf:"varint,1,opt,name=Rank,proto3" json:"Rank,omitempty"`
+	UserId string `protobuf:"bytes,2,opt,name=UserId,proto3" json:"UserId,omitempty"`
+	Score  int64  `protobuf:"varint,3,opt,name=Score,proto3" json:"Score,omitempty"`
+}
+
+func (x *LeaderboardEntry) Reset() {
+	*x = LeaderboardEntry{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_definitions_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LeaderboardEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaderboardEntry) ProtoMessage() {}
+
+func (x *LeaderboardEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_definitions_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaderboardEntry.ProtoReflect.Descriptor instead.
+func (*LeaderboardEntry) Descriptor() ([]byte, []int) {
+	return file_definitions_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *LeaderboardEntry) GetRank() int64 {
+	if x != nil {
+		return x.Rank
+	}
+	return 0
+}
+
+func (x *LeaderboardEntry) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *LeaderboardEntry) GetScore() int64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+type PaletteColor struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Color int32  `protobuf:"varint,1,opt,name=Color,proto3" json:"Color,omitempty"`
+	Hex   string `protobuf:"bytes,2,opt,name=Hex,proto3" json:"Hex,omitempty"`
+	Name  string `protobuf:"bytes,3,opt,name=Name,proto3" json:"Name,omitempty"`
+}
+
+func (x *PaletteColor) Reset() {
+	*x = PaletteColor{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_definitions_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PaletteColor) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PaletteColor) ProtoMessage() {}
+
+func (x *PaletteColor) ProtoReflect() protoreflect.Message {
+	mi := &file_definitions_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PaletteColor.ProtoReflect.Descriptor instead.
+func (*PaletteColor) Descriptor() ([]byte, []int) {
+	return file_definitions_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *PaletteColor) GetColor() int32 {
+	if x != nil {
+		return x.Color
+	}
+	return 0
+}
+
+func (x *PaletteColor) GetHex() string {
+	if x != nil {
+		return x.Hex
+	}
+	return ""
+}
+
+func (x *PaletteColor) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
 type ResponseMessage struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	MessageType       int32   `protobuf:"varint,1,opt,name=MessageType,proto3" json:"MessageType,omitempty"`
-	Message           string  `protobuf:"bytes,2,opt,name=Message,proto3" json:"Message,omitempty"`
-	Canvas            []int32 `protobuf:"varint,3,rep,packed,name=Canvas,proto3" json:"Canvas,omitempty"`
-	UserId            string  `protobuf:"bytes,4,opt,name=UserId,proto3" json:"UserId,omitempty"`
-	PixelId           int32   `protobuf:"varint,5,opt,name=PixelId,proto3" json:"PixelId,omitempty"`
-	Color             int32   `protobuf:"varint,6,opt,name=Color,proto3" json:"Color,omitempty"`
-	TimeStamp         int64   `protobuf:"varint,7,opt,name=TimeStamp,proto3" json:"TimeStamp,omitempty"`
-	CanvasWidth       int32   `protobuf:"varint,8,opt,name=CanvasWidth,proto3" json:"CanvasWidth,omitempty"`
-	CanvasHeight      int32   `protobuf:"varint,9,opt,name=CanvasHeight,proto3" json:"CanvasHeight,omitempty"`
-	UserCooldown      int32   `protobuf:"varint,10,opt,name=UserCooldown,proto3" json:"UserCooldown,omitempty"`
-	PixelCooldown     int32   `protobuf:"varint,11,opt,name=PixelCooldown,proto3" json:"PixelCooldown,omitempty"`
-	PingInterval      int32   `protobuf:"varint,12,opt,name=PingInterval,proto3" json:"PingInterval,omitempty"`
-	DisconnectTimeout int32   `protobuf:"varint,13,opt,name=DisconnectTimeout,proto3" json:"DisconnectTimeout,omitempty"`
+	MessageType              int32               `protobuf:"varint,1,opt,name=MessageType,proto3" json:"MessageType,omitempty"`
+	Message                  string              `protobuf:"bytes,2,opt,name=Message,proto3" json:"Message,omitempty"`
+	Canvas                   []int32             `protobuf:"varint,3,rep,packed,name=Canvas,proto3" json:"Canvas,omitempty"`
+	UserId                   string              `protobuf:"bytes,4,opt,name=UserId,proto3" json:"UserId,omitempty"`
+	PixelId                  int32               `protobuf:"varint,5,opt,name=PixelId,proto3" json:"PixelId,omitempty"`
+	Color                    int32               `protobuf:"varint,6,opt,name=Color,proto3" json:"Color,omitempty"`
+	TimeStamp                int64               `protobuf:"varint,7,opt,name=TimeStamp,proto3" json:"TimeStamp,omitempty"`
+	CanvasWidth              int32               `protobuf:"varint,8,opt,name=CanvasWidth,proto3" json:"CanvasWidth,omitempty"`
+	CanvasHeight             int32               `protobuf:"varint,9,opt,name=CanvasHeight,proto3" json:"CanvasHeight,omitempty"`
+	UserCooldown             int32               `protobuf:"varint,10,opt,name=UserCooldown,proto3" json:"UserCooldown,omitempty"`
+	PixelCooldown            int32               `protobuf:"varint,11,opt,name=PixelCooldown,proto3" json:"PixelCooldown,omitempty"`
+	PingInterval             int32               `protobuf:"varint,12,opt,name=PingInterval,proto3" json:"PingInterval,omitempty"`
+	DisconnectTimeout        int32               `protobuf:"varint,13,opt,name=DisconnectTimeout,proto3" json:"DisconnectTimeout,omitempty"`
+	RetryAfterMs             int64               `protobuf:"varint,14,opt,name=RetryAfterMs,proto3" json:"RetryAfterMs,omitempty"`
+	PixelsAvailable          int32               `protobuf:"varint,15,opt,name=PixelsAvailable,proto3" json:"PixelsAvailable,omitempty"`
+	NextPixelAt              int64               `protobuf:"varint,16,opt,name=NextPixelAt,proto3" json:"NextPixelAt,omitempty"`
+	UserCooldownRemainingMs  int64               `protobuf:"varint,17,opt,name=UserCooldownRemainingMs,proto3" json:"UserCooldownRemainingMs,omitempty"`
+	PixelCooldownRemainingMs int64               `protobuf:"varint,18,opt,name=PixelCooldownRemainingMs,proto3" json:"PixelCooldownRemainingMs,omitempty"`
+	CanvasState              string              `protobuf:"bytes,19,opt,name=CanvasState,proto3" json:"CanvasState,omitempty"`
+	StateStartsAt            int64               `protobuf:"varint,20,opt,name=StateStartsAt,proto3" json:"StateStartsAt,omitempty"`
+	StateEndsAt              int64               `protobuf:"varint,21,opt,name=StateEndsAt,proto3" json:"StateEndsAt,omitempty"`
+	CanvasIdentifier         string              `protobuf:"bytes,22,opt,name=CanvasIdentifier,proto3" json:"CanvasIdentifier,omitempty"`
+	Leaderboard              []*LeaderboardEntry `protobuf:"bytes,23,rep,name=Leaderboard,proto3" json:"Leaderboard,omitempty"`
+	LeaderboardTotal         int64               `protobuf:"varint,24,opt,name=LeaderboardTotal,proto3" json:"LeaderboardTotal,omitempty"`
+	Palette                  []*PaletteColor     `protobuf:"bytes,25,rep,name=Palette,proto3" json:"Palette,omitempty"`
+	Pixels                   []*Pixel            `protobuf:"bytes,26,rep,name=Pixels,proto3" json:"Pixels,omitempty"`
+	Reason                   Reason              `protobuf:"varint,27,opt,name=Reason,proto3,enum=Reason" json:"Reason,omitempty"`
+	// RequestId of the request a reply answers, or of the placement an update comes from for its placer
+	RequestId string `protobuf:"bytes,28,opt,name=RequestId,proto3" json:"RequestId,omitempty"`
+	// Session that published an event, only used between instances and never sent to clients
+	SessionId string `protobuf:"bytes,29,opt,name=SessionId,proto3" json:"SessionId,omitempty"`
 }
 
 func (x *ResponseMessage) Reset() {
 	*x = ResponseMessage{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_definitions_proto_msgTypes[1]
+		mi := &file_definitions_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -119,7 +507,7 @@ func (x *ResponseMessage) String() string {
 func (*ResponseMessage) ProtoMessage() {}
 
 func (x *ResponseMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_definitions_proto_msgTypes[1]
+	mi := &file_definitions_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -132,7 +520,7 @@ func (x *ResponseMessage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ResponseMessage.ProtoReflect.Descriptor instead.
 func (*ResponseMessage) Descriptor() ([]byte, []int) {
-	return file_definitions_proto_rawDescGZIP(), []int{1}
+	return file_definitions_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *ResponseMessage) GetMessageType() int32 {
@@ -226,82 +614,3115 @@ func (x *ResponseMessage) GetDisconnectTimeout() int32 {
 	return 0
 }
 
-var File_definitions_proto protoreflect.FileDescriptor
+func (x *ResponseMessage) GetRetryAfterMs() int64 {
+	if x != nil {
+		return x.RetryAfterMs
+	}
+	return 0
+}
 
-var file_definitions_proto_rawDesc = []byte{
-	0x0a, 0x11, 0x64, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x22, 0x62, 0x0a, 0x0e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x4d, 0x65,
-	0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
-	0x54, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x4d, 0x65, 0x73, 0x73,
-	0x61, 0x67, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x50, 0x69, 0x78, 0x65, 0x6c,
-	0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x50, 0x69, 0x78, 0x65, 0x6c, 0x49,
-	0x64, 0x12, 0x14, 0x0a, 0x05, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05,
-	0x52, 0x05, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x22, 0xad, 0x03, 0x0a, 0x0f, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x4d,
-	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x54, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05,
-	0x52, 0x0b, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x18, 0x0a,
-	0x07, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
-	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x43, 0x61, 0x6e, 0x76, 0x61,
-	0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x05, 0x52, 0x06, 0x43, 0x61, 0x6e, 0x76, 0x61, 0x73, 0x12,
-	0x16, 0x0a, 0x06, 0x55, 0x73, 0x65, 0x72, 0x49, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x06, 0x55, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x50, 0x69, 0x78, 0x65, 0x6c,
-	0x49, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x50, 0x69, 0x78, 0x65, 0x6c, 0x49,
-	0x64, 0x12, 0x14, 0x0a, 0x05, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05,
-	0x52, 0x05, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x12, 0x1c, 0x0a, 0x09, 0x54, 0x69, 0x6d, 0x65, 0x53,
-	0x74, 0x61, 0x6d, 0x70, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x54, 0x69, 0x6d, 0x65,
-	0x53, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x20, 0x0a, 0x0b, 0x43, 0x61, 0x6e, 0x76, 0x61, 0x73, 0x57,
-	0x69, 0x64, 0x74, 0x68, 0x18, 0x08, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x43, 0x61, 0x6e, 0x76,
-	0x61, 0x73, 0x57, 0x69, 0x64, 0x74, 0x68, 0x12, 0x22, 0x0a, 0x0c, 0x43, 0x61, 0x6e, 0x76, 0x61,
-	0x73, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x43,
-	0x61, 0x6e, 0x76, 0x61, 0x73, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x22, 0x0a, 0x0c, 0x55,
-	0x73, 0x65, 0x72, 0x43, 0x6f, 0x6f, 0x6c, 0x64, 0x6f, 0x77, 0x6e, 0x18, 0x0a, 0x20, 0x01, 0x28,
-	0x05, 0x52, 0x0c, 0x55, 0x73, 0x65, 0x72, 0x43, 0x6f, 0x6f, 0x6c, 0x64, 0x6f, 0x77, 0x6e, 0x12,
-	0x24, 0x0a, 0x0d, 0x50, 0x69, 0x78, 0x65, 0x6c, 0x43, 0x6f, 0x6f, 0x6c, 0x64, 0x6f, 0x77, 0x6e,
-	0x18, 0x0b, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0d, 0x50, 0x69, 0x78, 0x65, 0x6c, 0x43, 0x6f, 0x6f,
-	0x6c, 0x64, 0x6f, 0x77, 0x6e, 0x12, 0x22, 0x0a, 0x0c, 0x50, 0x69, 0x6e, 0x67, 0x49, 0x6e, 0x74,
-	0x65, 0x72, 0x76, 0x61, 0x6c, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x50, 0x69, 0x6e,
-	0x67, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x12, 0x2c, 0x0a, 0x11, 0x44, 0x69, 0x73,
-	0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18, 0x0d,
-	0x20, 0x01, 0x28, 0x05, 0x52, 0x11, 0x44, 0x69, 0x73, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74,
-	0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x42, 0x21, 0x5a, 0x1f, 0x67, 0x69, 0x74, 0x68, 0x75,
-	0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x72, 0x69, 0x73, 0x68, 0x69, 0x72, 0x61, 0x6a, 0x70, 0x61,
-	0x6c, 0x30, 0x31, 0x2f, 0x63, 0x61, 0x6e, 0x76, 0x61, 0x73, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x33,
+func (x *ResponseMessage) GetPixelsAvailable() int32 {
+	if x != nil {
+		return x.PixelsAvailable
+	}
+	return 0
 }
 
-var (
-	file_definitions_proto_rawDescOnce sync.Once
-	file_definitions_proto_rawDescData = file_definitions_proto_rawDesc
-)
+func (x *ResponseMessage) GetNextPixelAt() int64 {
+	if x != nil {
+		return x.NextPixelAt
+	}
+	return 0
+}
 
-func file_definitions_proto_rawDescGZIP() []byte {
-	file_definitions_proto_rawDescOnce.Do(func() {
-		file_definitions_proto_rawDescData = protoimpl.X.CompressGZIP(file_definitions_proto_rawDescData)
-	})
-	return file_definitions_proto_rawDescData
+func (x *ResponseMessage) GetUserCooldownRemainingMs() int64 {
+	if x != nil {
+		return x.UserCooldownRemainingMs
+	}
+	return 0
 }
 
-var file_definitions_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
-var file_definitions_proto_goTypes = []interface{}{
-	(*RequestMessage)(nil),  // 0: RequestMessage
-	(*ResponseMessage)(nil), // 1: ResponseMessage
+func (x *ResponseMessage) GetPixelCooldownRemainingMs() int64 {
+	if x != nil {
+		return x.PixelCooldownRemainingMs
+	}
+	return 0
 }
-var file_definitions_proto_depIdxs = []int32{
-	0, // [0:0] is the sub-list for method output_type
-	0, // [0:0] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+
+func (x *ResponseMessage) GetCanvasState() string {
+	if x != nil {
+		return x.CanvasState
+	}
+	return ""
 }
 
-func init() { file_definitions_proto_init() }
-func file_definitions_proto_init() {
-	if File_definitions_proto != nil {
-		return
+func (x *ResponseMessage) GetStateStartsAt() int64 {
+	if x != nil {
+		return x.StateStartsAt
 	}
-	if !protoimpl.UnsafeEnabled {
-		file_definitions_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RequestMessage); i {
+	return 0
+}
+
+func (x *ResponseMessage) GetStateEndsAt() int64 {
+	if x != nil {
+		return x.StateEndsAt
+	}
+	return 0
+}
+
+func (x *ResponseMessage) GetCanvasIdentifier() string {
+	if x != nil {
+		return x.CanvasIdentifier
+	}
+	return ""
+}
+
+func (x *ResponseMessage) GetLeaderboard() []*LeaderboardEntry {
+	if x != nil {
+		return x.Leaderboard
+	}
+	return nil
+}
+
+func (x *ResponseMessage) GetLeaderboardTotal() int64 {
+	if x != nil {
+		return x.LeaderboardTotal
+	}
+	return 0
+}
+
+func (x *ResponseMessage) GetPalette() []*PaletteColor {
+	if x != nil {
+		return x.Palette
+	}
+	return nil
+}
+
+func (x *ResponseMessage) GetPixels() []*Pixel {
+	if x != nil {
+		return x.Pixels
+	}
+	return nil
+}
+
+func (x *ResponseMessage) GetReason() Reason {
+	if x != nil {
+		return x.Reason
+	}
+	return Reason_NONE
+}
+
+func (x *ResponseMessage) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+func (x *ResponseMessage) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+type GetConfigRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CanvasIdentifier string `protobuf:"bytes,1,opt,name=CanvasIdentifier,proto3" json:"CanvasIdentifier,omitempty"`
+}
+
+func (x *GetConfigRequest) Reset() {
+	*x = GetConfigRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_definitions_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetConfigRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetConfigRequest) ProtoMessage() {}
+
+func (x *GetConfigRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_definitions_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetConfigRequest.ProtoReflect.Descriptor instead.
+func (*GetConfigRequest) Descriptor() ([]byte, []int) {
+	return file_definitions_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetConfigRequest) GetCanvasIdentifier() string {
+	if x != nil {
+		return x.CanvasIdentifier
+	}
+	return ""
+}
+
+type GetCanvasRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CanvasIdentifier string `protobuf:"bytes,1,opt,name=CanvasIdentifier,proto3" json:"CanvasIdentifier,omitempty"`
+}
+
+func (x *GetCanvasRequest) Reset() {
+	*x = GetCanvasRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_definitions_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetCanvasRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCanvasRequest) ProtoMessage() {}
+
+func (x *GetCanvasRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_definitions_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCanvasRequest.ProtoReflect.Descriptor instead.
+func (*GetCanvasRequest) Descriptor() ([]byte, []int) {
+	return file_definitions_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetCanvasRequest) GetCanvasIdentifier() string {
+	if x != nil {
+		return x.CanvasIdentifier
+	}
+	return ""
+}
+
+type SetPixelRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PixelId int32 `protobuf:"varint,1,opt,name=PixelId,proto3" json:"PixelId,omitempty"`
+	Color   int32 `protobuf:"varint,2,opt,name=Color,proto3" json:"Color,omitempty"`
+}
+
+func (x *SetPixelRequest) Reset() {
+	*x = SetPixelRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_definitions_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetPixelRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetPixelRequest) ProtoMessage() {}
+
+func (x *SetPixelRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_definitions_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetPixelRequest.ProtoReflect.Descriptor instead.
+func (*SetPixelRequest) Descriptor() ([]byte, []int) {
+	return file_definitions_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *SetPixelRequest) GetPixelId() int32 {
+	if x != nil {
+		return x.PixelId
+	}
+	return 0
+}
+
+func (x *SetPixelRequest) GetColor() int32 {
+	if x != nil {
+		return x.Color
+	}
+	return 0
+}
+
+type ViewPixelRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PixelId int32 `protobuf:"varint,1,opt,name=PixelId,proto3" json:"PixelId,omitempty"`
+}
+
+func (x *ViewPixelRequest) Reset() {
+	*x = ViewPixelRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_definitions_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ViewPixelRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ViewPixelRequest) ProtoMessage() {}
+
+func (x *ViewPixelRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_definitions_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ViewPixelRequest.ProtoReflect.Descriptor instead.
+func (*ViewPixelRequest) Descriptor() ([]byte, []int) {
+	return file_definitions_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ViewPixelRequest) GetPixelId() int32 {
+	if x != nil {
+		return x.PixelId
+	}
+	return 0
+}
+
+type GetCooldownRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PixelId int32 `protobuf:"varint,1,opt,name=PixelId,proto3" json:"PixelId,omitempty"`
+}
+
+func (x *GetCooldownRequest) Reset() {
+	*x = GetCooldownRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_definitions_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetCooldownRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCooldownRequest) ProtoMessage() {}
+
+func (x *GetCooldownRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_definitions_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCooldownRequest.ProtoReflect.Descriptor instead.
+func (*GetCooldownRequest) Descriptor() ([]byte, []int) {
+	return file_definitions_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetCooldownRequest) GetPixelId() int32 {
+	if x != nil {
+		return x.PixelId
+	}
+	return 0
+}
+
+type GetLeaderboardRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Window string `protobuf:"bytes,1,opt,name=Window,proto3" json:"Window,omitempty"`
+	Offset int32  `protobuf:"varint,2,opt,name=Offset,proto3" json:"Offset,omitempty"`
+	Limit  int32  `protobuf:"varint,3,opt,name=Limit,proto3" json:"Limit,omitempty"`
+}
+
+func (x *GetLeaderboardRequest) Reset() {
+	*x = GetLeaderboardRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_definitions_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetLeaderboardRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLeaderboardRequest) ProtoMessage() {}
+
+func (x *GetLeaderboardRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_definitions_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLeaderboardRequest.ProtoReflect.Descriptor instead.
+func (*GetLeaderboardRequest) Descriptor() ([]byte, []int) {
+	return file_definitions_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetLeaderboardRequest) GetWindow() string {
+	if x != nil {
+		return x.Window
+	}
+	return ""
+}
+
+func (x *GetLeaderboardRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *GetLeaderboardRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type SetPixelsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Brush     int32   `protobuf:"varint,1,opt,name=Brush,proto3" json:"Brush,omitempty"`
+	PixelIds  []int32 `protobuf:"varint,2,rep,packed,name=PixelIds,proto3" json:"PixelIds,omitempty"`
+	PixelId   int32   `protobuf:"varint,3,opt,name=PixelId,proto3" json:"PixelId,omitempty"`
+	ToPixelId int32   `protobuf:"varint,4,opt,name=ToPixelId,proto3" json:"ToPixelId,omitempty"`
+	BrushSize int32   `protobuf:"varint,5,opt,name=BrushSize,proto3" json:"BrushSize,omitempty"`
+	Color     int32   `protobuf:"varint,6,opt,name=Color,proto3" json:"Color,omitempty"`
+}
+
+func (x *SetPixelsRequest) Reset() {
+	*x = SetPixelsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_definitions_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetPixelsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetPixelsRequest) ProtoMessage() {}
+
+func (x *SetPixelsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_definitions_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetPixelsRequest.ProtoReflect.Descriptor instead.
+func (*SetPixelsRequest) Descriptor() ([]byte, []int) {
+	return file_definitions_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *SetPixelsRequest) GetBrush() int32 {
+	if x != nil {
+		return x.Brush
+	}
+	return 0
+}
+
+func (x *SetPixelsRequest) GetPixelIds() []int32 {
+	if x != nil {
+		return x.PixelIds
+	}
+	return nil
+}
+
+func (x *SetPixelsRequest) GetPixelId() int32 {
+	if x != nil {
+		return x.PixelId
+	}
+	return 0
+}
+
+func (x *SetPixelsRequest) GetToPixelId() int32 {
+	if x != nil {
+		return x.ToPixelId
+	}
+	return 0
+}
+
+func (x *SetPixelsRequest) GetBrushSize() int32 {
+	if x != nil {
+		return x.BrushSize
+	}
+	return 0
+}
+
+func (x *SetPixelsRequest) GetColor() int32 {
+	if x != nil {
+		return x.Color
+	}
+	return 0
+}
+
+type UndoPlacementRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *UndoPlacementRequest) Reset() {
+	*x = UndoPlacementRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_definitions_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UndoPlacementRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UndoPlacementRequest) ProtoMessage() {}
+
+func (x *UndoPlacementRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_definitions_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UndoPlacementRequest.ProtoReflect.Descriptor instead.
+func (*UndoPlacementRequest) Descriptor() ([]byte, []int) {
+	return file_definitions_proto_rawDescGZIP(), []int{12}
+}
+
+type JoinCanvasRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CanvasIdentifier string `protobuf:"bytes,1,opt,name=CanvasIdentifier,proto3" json:"CanvasIdentifier,omitempty"`
+	Watch            bool   `protobuf:"varint,2,opt,name=Watch,proto3" json:"Watch,omitempty"`
+}
+
+func (x *JoinCanvasRequest) Reset() {
+	*x = JoinCanvasRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_definitions_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *JoinCanvasRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JoinCanvasRequest) ProtoMessage() {}
+
+func (x *JoinCanvasRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_definitions_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JoinCanvasRequest.ProtoReflect.Descriptor instead.
+func (*JoinCanvasRequest) Descriptor() ([]byte, []int) {
+	return file_definitions_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *JoinCanvasRequest) GetCanvasIdentifier() string {
+	if x != nil {
+		return x.CanvasIdentifier
+	}
+	return ""
+}
+
+func (x *JoinCanvasRequest) GetWatch() bool {
+	if x != nil {
+		return x.Watch
+	}
+	return false
+}
+
+type ClientEnvelope struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Payload:
+	//	*ClientEnvelope_GetConfig
+	//	*ClientEnvelope_GetCanvas
+	//	*ClientEnvelope_SetPixel
+	//	*ClientEnvelope_ViewPixel
+	//	*ClientEnvelope_GetCooldown
+	//	*ClientEnvelope_GetLeaderboard
+	//	*ClientEnvelope_SetPixels
+	//	*ClientEnvelope_UndoPlacement
+	//	*ClientEnvelope_JoinCanvas
+	Payload   isClientEnvelope_Payload `protobuf_oneof:"Payload"`
+	RequestId string                   `protobuf:"bytes,9,opt,name=RequestId,proto3" json:"RequestId,omitempty"`
+}
+
+func (x *ClientEnvelope) Reset() {
+	*x = ClientEnvelope{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_definitions_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ClientEnvelope) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClientEnvelope) ProtoMessage() {}
+
+func (x *ClientEnvelope) ProtoReflect() protoreflect.Message {
+	mi := &file_definitions_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClientEnvelope.ProtoReflect.Descriptor instead.
+func (*ClientEnvelope) Descriptor() ([]byte, []int) {
+	return file_definitions_proto_rawDescGZIP(), []int{14}
+}
+
+func (m *ClientEnvelope) GetPayload() isClientEnvelope_Payload {
+	if m != nil {
+		return m.Payload
+	}
+	return nil
+}
+
+func (x *ClientEnvelope) GetGetConfig() *GetConfigRequest {
+	if x, ok := x.GetPayload().(*ClientEnvelope_GetConfig); ok {
+		return x.GetConfig
+	}
+	return nil
+}
+
+func (x *ClientEnvelope) GetGetCanvas() *GetCanvasRequest {
+	if x, ok := x.GetPayload().(*ClientEnvelope_GetCanvas); ok {
+		return x.GetCanvas
+	}
+	return nil
+}
+
+func (x *ClientEnvelope) GetSetPixel() *SetPixelRequest {
+	if x, ok := x.GetPayload().(*ClientEnvelope_SetPixel); ok {
+		return x.SetPixel
+	}
+	return nil
+}
+
+func (x *ClientEnvelope) GetViewPixel() *ViewPixelRequest {
+	if x, ok := x.GetPayload().(*ClientEnvelope_ViewPixel); ok {
+		return x.ViewPixel
+	}
+	return nil
+}
+
+func (x *ClientEnvelope) GetGetCooldown() *GetCooldownRequest {
+	if x, ok := x.GetPayload().(*ClientEnvelope_GetCooldown); ok {
+		return x.GetCooldown
+	}
+	return nil
+}
+
+func (x *ClientEnvelope) GetGetLeaderboard() *GetLeaderboardRequest {
+	if x, ok := x.GetPayload().(*ClientEnvelope_GetLeaderboard); ok {
+		return x.GetLeaderboard
+	}
+	return nil
+}
+
+func (x *ClientEnvelope) GetSetPixels() *SetPixelsRequest {
+	if x, ok := x.GetPayload().(*ClientEnvelope_SetPixels); ok {
+		return x.SetPixels
+	}
+	return nil
+}
+
+func (x *ClientEnvelope) GetUndoPlacement() *UndoPlacementRequest {
+	if x, ok := x.GetPayload().(*ClientEnvelope_UndoPlacement); ok {
+		return x.UndoPlacement
+	}
+	return nil
+}
+
+func (x *ClientEnvelope) GetJoinCanvas() *JoinCanvasRequest {
+	if x, ok := x.GetPayload().(*ClientEnvelope_JoinCanvas); ok {
+		return x.JoinCanvas
+	}
+	return nil
+}
+
+func (x *ClientEnvelope) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+type isClientEnvelope_Payload interface {
+	isClientEnvelope_Payload()
+}
+
+type ClientEnvelope_GetConfig struct {
+	GetConfig *GetConfigRequest `protobuf:"bytes,1,opt,name=GetConfig,proto3,oneof"`
+}
+
+type ClientEnvelope_GetCanvas struct {
+	GetCanvas *GetCanvasRequest `protobuf:"bytes,2,opt,name=GetCanvas,proto3,oneof"`
+}
+
+type ClientEnvelope_SetPixel struct {
+	SetPixel *SetPixelRequest `protobuf:"bytes,3,opt,name=SetPixel,proto3,oneof"`
+}
+
+type ClientEnvelope_ViewPixel struct {
+	ViewPixel *ViewPixelRequest `protobuf:"bytes,4,opt,name=ViewPixel,proto3,oneof"`
+}
+
+type ClientEnvelope_GetCooldown struct {
+	GetCooldown *GetCooldownRequest `protobuf:"bytes,5,opt,name=GetCooldown,proto3,oneof"`
+}
+
+type ClientEnvelope_GetLeaderboard struct {
+	GetLeaderboard *GetLeaderboardRequest `protobuf:"bytes,6,opt,name=GetLeaderboard,proto3,oneof"`
+}
+
+type ClientEnvelope_SetPixels struct {
+	SetPixels *SetPixelsRequest `protobuf:"bytes,7,opt,name=SetPixels,proto3,oneof"`
+}
+
+type ClientEnvelope_UndoPlacement struct {
+	UndoPlacement *UndoPlacementRequest `protobuf:"bytes,8,opt,name=UndoPlacement,proto3,oneof"`
+}
+
+type ClientEnvelope_JoinCanvas struct {
+	JoinCanvas *JoinCanvasRequest `protobuf:"bytes,10,opt,name=JoinCanvas,proto3,oneof"`
+}
+
+func (*ClientEnvelope_GetConfig) isClientEnvelope_Payload() {}
+
+func (*ClientEnvelope_GetCanvas) isClientEnvelope_Payload() {}
+
+func (*ClientEnvelope_SetPixel) isClientEnvelope_Payload() {}
+
+func (*ClientEnvelope_ViewPixel) isClientEnvelope_Payload() {}
+
+func (*ClientEnvelope_GetCooldown) isClientEnvelope_Payload() {}
+
+func (*ClientEnvelope_GetLeaderboard) isClientEnvelope_Payload() {}
+
+func (*ClientEnvelope_SetPixels) isClientEnvelope_Payload() {}
+
+func (*ClientEnvelope_UndoPlacement) isClientEnvelope_Payload() {}
+
+func (*ClientEnvelope_JoinCanvas) isClientEnvelope_Payload() {}
+
+type Config struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CanvasWidth       int32           `protobuf:"varint,1,opt,name=CanvasWidth,proto3" json:"CanvasWidth,omitempty"`
+	CanvasHeight      int32           `protobuf:"varint,2,opt,name=CanvasHeight,proto3" json:"CanvasHeight,omitempty"`
+	UserCooldown      int32           `protobuf:"varint,3,opt,name=UserCooldown,proto3" json:"UserCooldown,omitempty"`
+	PixelCooldown     int32           `protobuf:"varint,4,opt,name=PixelCooldown,proto3" json:"PixelCooldown,omitempty"`
+	PingInterval      int32           `protobuf:"varint,5,opt,name=PingInterval,proto3" json:"PingInterval,omitempty"`
+	DisconnectTimeout int32           `protobuf:"varint,6,opt,name=DisconnectTimeout,proto3" json:"DisconnectTimeout,omitempty"`
+	Phase             *CanvasPhase    `protobuf:"bytes,7,opt,name=Phase,proto3" json:"Phase,omitempty"`
+	PixelsAvailable   int32           `protobuf:"varint,8,opt,name=PixelsAvailable,proto3" json:"PixelsAvailable,omitempty"`
+	NextPixelAt       int64           `protobuf:"varint,9,opt,name=NextPixelAt,proto3" json:"NextPixelAt,omitempty"`
+	Palette           []*PaletteColor `protobuf:"bytes,10,rep,name=Palette,proto3" json:"Palette,omitempty"`
+	CanvasIdentifier  string          `protobuf:"bytes,11,opt,name=CanvasIdentifier,proto3" json:"CanvasIdentifier,omitempty"`
+}
+
+func (x *Config) Reset() {
+	*x = Config{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_definitions_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Config) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Config) ProtoMessage() {}
+
+func (x *Config) ProtoReflect() protoreflect.Message {
+	mi := &file_definitions_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Config.ProtoReflect.Descriptor instead.
+func (*Config) Descriptor() ([]byte, []int) {
+	return file_definitions_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *Config) GetCanvasWidth() int32 {
+	if x != nil {
+		return x.CanvasWidth
+	}
+	return 0
+}
+
+func (x *Config) GetCanvasHeight() int32 {
+	if x != nil {
+		return x.CanvasHeight
+	}
+	return 0
+}
+
+func (x *Config) GetUserCooldown() int32 {
+	if x != nil {
+		return x.UserCooldown
+	}
+	return 0
+}
+
+func (x *Config) GetPixelCooldown() int32 {
+	if x != nil {
+		return x.PixelCooldown
+	}
+	return 0
+}
+
+func (x *Config) GetPingInterval() int32 {
+	if x != nil {
+		return x.PingInterval
+	}
+	return 0
+}
+
+func (x *Config) GetDisconnectTimeout() int32 {
+	if x != nil {
+		return x.DisconnectTimeout
+	}
+	return 0
+}
+
+func (x *Config) GetPhase() *CanvasPhase {
+	if x != nil {
+		return x.Phase
+	}
+	return nil
+}
+
+func (x *Config) GetPixelsAvailable() int32 {
+	if x != nil {
+		return x.PixelsAvailable
+	}
+	return 0
+}
+
+func (x *Config) GetNextPixelAt() int64 {
+	if x != nil {
+		return x.NextPixelAt
+	}
+	return 0
+}
+
+func (x *Config) GetPalette() []*PaletteColor {
+	if x != nil {
+		return x.Palette
+	}
+	return nil
+}
+
+func (x *Config) GetCanvasIdentifier() string {
+	if x != nil {
+		return x.CanvasIdentifier
+	}
+	return ""
+}
+
+type CanvasSnapshot struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Canvas           []int32 `protobuf:"varint,1,rep,packed,name=Canvas,proto3" json:"Canvas,omitempty"`
+	CanvasIdentifier string  `protobuf:"bytes,2,opt,name=CanvasIdentifier,proto3" json:"CanvasIdentifier,omitempty"`
+}
+
+func (x *CanvasSnapshot) Reset() {
+	*x = CanvasSnapshot{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_definitions_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CanvasSnapshot) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CanvasSnapshot) ProtoMessage() {}
+
+func (x *CanvasSnapshot) ProtoReflect() protoreflect.Message {
+	mi := &file_definitions_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CanvasSnapshot.ProtoReflect.Descriptor instead.
+func (*CanvasSnapshot) Descriptor() ([]byte, []int) {
+	return file_definitions_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *CanvasSnapshot) GetCanvas() []int32 {
+	if x != nil {
+		return x.Canvas
+	}
+	return nil
+}
+
+func (x *CanvasSnapshot) GetCanvasIdentifier() string {
+	if x != nil {
+		return x.CanvasIdentifier
+	}
+	return ""
+}
+
+type PixelInfo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PixelId int32  `protobuf:"varint,1,opt,name=PixelId,proto3" json:"PixelId,omitempty"`
+	Color   int32  `protobuf:"varint,2,opt,name=Color,proto3" json:"Color,omitempty"`
+	UserId  string `protobuf:"bytes,3,opt,name=UserId,proto3" json:"UserId,omitempty"`
+}
+
+func (x *PixelInfo) Reset() {
+	*x = PixelInfo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_definitions_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PixelInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PixelInfo) ProtoMessage() {}
+
+func (x *PixelInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_definitions_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PixelInfo.ProtoReflect.Descriptor instead.
+func (*PixelInfo) Descriptor() ([]byte, []int) {
+	return file_definitions_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *PixelInfo) GetPixelId() int32 {
+	if x != nil {
+		return x.PixelId
+	}
+	return 0
+}
+
+func (x *PixelInfo) GetColor() int32 {
+	if x != nil {
+		return x.Color
+	}
+	return 0
+}
+
+func (x *PixelInfo) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type Cooldown struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PixelId                  int32 `protobuf:"varint,1,opt,name=PixelId,proto3" json:"PixelId,omitempty"`
+	UserCooldownRemainingMs  int64 `protobuf:"varint,2,opt,name=UserCooldownRemainingMs,proto3" json:"UserCooldownRemainingMs,omitempty"`
+	PixelCooldownRemainingMs int64 `protobuf:"varint,3,opt,name=PixelCooldownRemainingMs,proto3" json:"PixelCooldownRemainingMs,omitempty"`
+	PixelsAvailable          int32 `protobuf:"varint,4,opt,name=PixelsAvailable,proto3" json:"PixelsAvailable,omitempty"`
+	NextPixelAt              int64 `protobuf:"varint,5,opt,name=NextPixelAt,proto3" json:"NextPixelAt,omitempty"`
+}
+
+func (x *Cooldown) Reset() {
+	*x = Cooldown{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_definitions_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Cooldown) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Cooldown) ProtoMessage() {}
+
+func (x *Cooldown) ProtoReflect() protoreflect.Message {
+	mi := &file_definitions_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Cooldown.ProtoReflect.Descriptor instead.
+func (*Cooldown) Descriptor() ([]byte, []int) {
+	return file_definitions_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *Cooldown) GetPixelId() int32 {
+	if x != nil {
+		return x.PixelId
+	}
+	return 0
+}
+
+func (x *Cooldown) GetUserCooldownRemainingMs() int64 {
+	if x != nil {
+		return x.UserCooldownRemainingMs
+	}
+	return 0
+}
+
+func (x *Cooldown) GetPixelCooldownRemainingMs() int64 {
+	if x != nil {
+		return x.PixelCooldownRemainingMs
+	}
+	return 0
+}
+
+func (x *Cooldown) GetPixelsAvailable() int32 {
+	if x != nil {
+		return x.PixelsAvailable
+	}
+	return 0
+}
+
+func (x *Cooldown) GetNextPixelAt() int64 {
+	if x != nil {
+		return x.NextPixelAt
+	}
+	return 0
+}
+
+type LeaderboardPage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Entries []*LeaderboardEntry `protobuf:"bytes,1,rep,name=Entries,proto3" json:"Entries,omitempty"`
+	Total   int64               `protobuf:"varint,2,opt,name=Total,proto3" json:"Total,omitempty"`
+}
+
+func (x *LeaderboardPage) Reset() {
+	*x = LeaderboardPage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_definitions_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LeaderboardPage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaderboardPage) ProtoMessage() {}
+
+func (x *LeaderboardPage) ProtoReflect() protoreflect.Message {
+	mi := &file_definitions_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaderboardPage.ProtoReflect.Descriptor instead.
+func (*LeaderboardPage) Descriptor() ([]byte, []int) {
+	return file_definitions_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *LeaderboardPage) GetEntries() []*LeaderboardEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+func (x *LeaderboardPage) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+type Placed struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Message          string   `protobuf:"bytes,1,opt,name=Message,proto3" json:"Message,omitempty"`
+	Pixels           []*Pixel `protobuf:"bytes,2,rep,name=Pixels,proto3" json:"Pixels,omitempty"`
+	CanvasIdentifier string   `protobuf:"bytes,3,opt,name=CanvasIdentifier,proto3" json:"CanvasIdentifier,omitempty"`
+}
+
+func (x *Placed) Reset() {
+	*x = Placed{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_definitions_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Placed) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Placed) ProtoMessage() {}
+
+func (x *Placed) ProtoReflect() protoreflect.Message {
+	mi := &file_definitions_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Placed.ProtoReflect.Descriptor instead.
+func (*Placed) Descriptor() ([]byte, []int) {
+	return file_definitions_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *Placed) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *Placed) GetPixels() []*Pixel {
+	if x != nil {
+		return x.Pixels
+	}
+	return nil
+}
+
+func (x *Placed) GetCanvasIdentifier() string {
+	if x != nil {
+		return x.CanvasIdentifier
+	}
+	return ""
+}
+
+type PixelUpdate struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserId           string `protobuf:"bytes,1,opt,name=UserId,proto3" json:"UserId,omitempty"`
+	PixelId          int32  `protobuf:"varint,2,opt,name=PixelId,proto3" json:"PixelId,omitempty"`
+	Color            int32  `protobuf:"varint,3,opt,name=Color,proto3" json:"Color,omitempty"`
+	CanvasIdentifier string `protobuf:"bytes,4,opt,name=CanvasIdentifier,proto3" json:"CanvasIdentifier,omitempty"`
+}
+
+func (x *PixelUpdate) Reset() {
+	*x = PixelUpdate{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_definitions_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PixelUpdate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PixelUpdate) ProtoMessage() {}
+
+func (x *PixelUpdate) ProtoReflect() protoreflect.Message {
+	mi := &file_definitions_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PixelUpdate.ProtoReflect.Descriptor instead.
+func (*PixelUpdate) Descriptor() ([]byte, []int) {
+	return file_definitions_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *PixelUpdate) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *PixelUpdate) GetPixelId() int32 {
+	if x != nil {
+		return x.PixelId
+	}
+	return 0
+}
+
+func (x *PixelUpdate) GetColor() int32 {
+	if x != nil {
+		return x.Color
+	}
+	return 0
+}
+
+func (x *PixelUpdate) GetCanvasIdentifier() string {
+	if x != nil {
+		return x.CanvasIdentifier
+	}
+	return ""
+}
+
+type PixelBatch struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserId           string   `protobuf:"bytes,1,opt,name=UserId,proto3" json:"UserId,omitempty"`
+	Pixels           []*Pixel `protobuf:"bytes,2,rep,name=Pixels,proto3" json:"Pixels,omitempty"`
+	CanvasIdentifier string   `protobuf:"bytes,3,opt,name=CanvasIdentifier,proto3" json:"CanvasIdentifier,omitempty"`
+}
+
+func (x *PixelBatch) Reset() {
+	*x = PixelBatch{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_definitions_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PixelBatch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PixelBatch) ProtoMessage() {}
+
+func (x *PixelBatch) ProtoReflect() protoreflect.Message {
+	mi := &file_definitions_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PixelBatch.ProtoReflect.Descriptor instead.
+func (*PixelBatch) Descriptor() ([]byte, []int) {
+	return file_definitions_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *PixelBatch) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *PixelBatch) GetPixels() []*Pixel {
+	if x != nil {
+		return x.Pixels
+	}
+	return nil
+}
+
+func (x *PixelBatch) GetCanvasIdentifier() string {
+	if x != nil {
+		return x.CanvasIdentifier
+	}
+	return ""
+}
+
+type Rejection struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Reason                   Reason       `protobuf:"varint,1,opt,name=Reason,proto3,enum=Reason" json:"Reason,omitempty"`
+	Message                  string       `protobuf:"bytes,2,opt,name=Message,proto3" json:"Message,omitempty"`
+	PixelId                  int32        `protobuf:"varint,3,opt,name=PixelId,proto3" json:"PixelId,omitempty"`
+	Color                    int32        `protobuf:"varint,4,opt,name=Color,proto3" json:"Color,omitempty"`
+	RetryAfterMs             int64        `protobuf:"varint,5,opt,name=RetryAfterMs,proto3" json:"RetryAfterMs,omitempty"`
+	UserCooldownRemainingMs  int64        `protobuf:"varint,6,opt,name=UserCooldownRemainingMs,proto3" json:"UserCooldownRemainingMs,omitempty"`
+	PixelCooldownRemainingMs int64        `protobuf:"varint,7,opt,name=PixelCooldownRemainingMs,proto3" json:"PixelCooldownRemainingMs,omitempty"`
+	PixelsAvailable          int32        `protobuf:"varint,8,opt,name=PixelsAvailable,proto3" json:"PixelsAvailable,omitempty"`
+	NextPixelAt              int64        `protobuf:"varint,9,opt,name=NextPixelAt,proto3" json:"NextPixelAt,omitempty"`
+	Phase                    *CanvasPhase `protobuf:"bytes,10,opt,name=Phase,proto3" json:"Phase,omitempty"`
+}
+
+func (x *Rejection) Reset() {
+	*x = Rejection{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_definitions_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Rejection) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Rejection) ProtoMessage() {}
+
+func (x *Rejection) ProtoReflect() protoreflect.Message {
+	mi := &file_definitions_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Rejection.ProtoReflect.Descriptor instead.
+func (*Rejection) Descriptor() ([]byte, []int) {
+	return file_definitions_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *Rejection) GetReason() Reason {
+	if x != nil {
+		return x.Reason
+	}
+	return Reason_NONE
+}
+
+func (x *Rejection) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *Rejection) GetPixelId() int32 {
+	if x != nil {
+		return x.PixelId
+	}
+	return 0
+}
+
+func (x *Rejection) GetColor() int32 {
+	if x != nil {
+		return x.Color
+	}
+	return 0
+}
+
+func (x *Rejection) GetRetryAfterMs() int64 {
+	if x != nil {
+		return x.RetryAfterMs
+	}
+	return 0
+}
+
+func (x *Rejection) GetUserCooldownRemainingMs() int64 {
+	if x != nil {
+		return x.UserCooldownRemainingMs
+	}
+	return 0
+}
+
+func (x *Rejection) GetPixelCooldownRemainingMs() int64 {
+	if x != nil {
+		return x.PixelCooldownRemainingMs
+	}
+	return 0
+}
+
+func (x *Rejection) GetPixelsAvailable() int32 {
+	if x != nil {
+		return x.PixelsAvailable
+	}
+	return 0
+}
+
+func (x *Rejection) GetNextPixelAt() int64 {
+	if x != nil {
+		return x.NextPixelAt
+	}
+	return 0
+}
+
+func (x *Rejection) GetPhase() *CanvasPhase {
+	if x != nil {
+		return x.Phase
+	}
+	return nil
+}
+
+type CreditBalance struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PixelsAvailable int32 `protobuf:"varint,1,opt,name=PixelsAvailable,proto3" json:"PixelsAvailable,omitempty"`
+	NextPixelAt     int64 `protobuf:"varint,2,opt,name=NextPixelAt,proto3" json:"NextPixelAt,omitempty"`
+}
+
+func (x *CreditBalance) Reset() {
+	*x = CreditBalance{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_definitions_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreditBalance) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreditBalance) ProtoMessage() {}
+
+func (x *CreditBalance) ProtoReflect() protoreflect.Message {
+	mi := &file_definitions_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreditBalance.ProtoReflect.Descriptor instead.
+func (*CreditBalance) Descriptor() ([]byte, []int) {
+	return file_definitions_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *CreditBalance) GetPixelsAvailable() int32 {
+	if x != nil {
+		return x.PixelsAvailable
+	}
+	return 0
+}
+
+func (x *CreditBalance) GetNextPixelAt() int64 {
+	if x != nil {
+		return x.NextPixelAt
+	}
+	return 0
+}
+
+type CanvasPhase struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	State    string `protobuf:"bytes,1,opt,name=State,proto3" json:"State,omitempty"`
+	StartsAt int64  `protobuf:"varint,2,opt,name=StartsAt,proto3" json:"StartsAt,omitempty"`
+	EndsAt   int64  `protobuf:"varint,3,opt,name=EndsAt,proto3" json:"EndsAt,omitempty"`
+	// Only set on CanvasState events
+	CanvasIdentifier string `protobuf:"bytes,4,opt,name=CanvasIdentifier,proto3" json:"CanvasIdentifier,omitempty"`
+}
+
+func (x *CanvasPhase) Reset() {
+	*x = CanvasPhase{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_definitions_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CanvasPhase) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CanvasPhase) ProtoMessage() {}
+
+func (x *CanvasPhase) ProtoReflect() protoreflect.Message {
+	mi := &file_definitions_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CanvasPhase.ProtoReflect.Descriptor instead.
+func (*CanvasPhase) Descriptor() ([]byte, []int) {
+	return file_definitions_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *CanvasPhase) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *CanvasPhase) GetStartsAt() int64 {
+	if x != nil {
+		return x.StartsAt
+	}
+	return 0
+}
+
+func (x *CanvasPhase) GetEndsAt() int64 {
+	if x != nil {
+		return x.EndsAt
+	}
+	return 0
+}
+
+func (x *CanvasPhase) GetCanvasIdentifier() string {
+	if x != nil {
+		return x.CanvasIdentifier
+	}
+	return ""
+}
+
+type CanvasResize struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CanvasIdentifier string `protobuf:"bytes,1,opt,name=CanvasIdentifier,proto3" json:"CanvasIdentifier,omitempty"`
+	CanvasWidth      int32  `protobuf:"varint,2,opt,name=CanvasWidth,proto3" json:"CanvasWidth,omitempty"`
+	CanvasHeight     int32  `protobuf:"varint,3,opt,name=CanvasHeight,proto3" json:"CanvasHeight,omitempty"`
+}
+
+func (x *CanvasResize) Reset() {
+	*x = CanvasResize{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_definitions_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CanvasResize) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CanvasResize) ProtoMessage() {}
+
+func (x *CanvasResize) ProtoReflect() protoreflect.Message {
+	mi := &file_definitions_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CanvasResize.ProtoReflect.Descriptor instead.
+func (*CanvasResize) Descriptor() ([]byte, []int) {
+	return file_definitions_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *CanvasResize) GetCanvasIdentifier() string {
+	if x != nil {
+		return x.CanvasIdentifier
+	}
+	return ""
+}
+
+func (x *CanvasResize) GetCanvasWidth() int32 {
+	if x != nil {
+		return x.CanvasWidth
+	}
+	return 0
+}
+
+func (x *CanvasResize) GetCanvasHeight() int32 {
+	if x != nil {
+		return x.CanvasHeight
+	}
+	return 0
+}
+
+type ServerEnvelope struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Payload:
+	//	*ServerEnvelope_Config
+	//	*ServerEnvelope_Canvas
+	//	*ServerEnvelope_Pixel
+	//	*ServerEnvelope_Cooldown
+	//	*ServerEnvelope_Leaderboard
+	//	*ServerEnvelope_Placed
+	//	*ServerEnvelope_Update
+	//	*ServerEnvelope_UpdateBatch
+	//	*ServerEnvelope_Rejection
+	//	*ServerEnvelope_Credits
+	//	*ServerEnvelope_CanvasState
+	//	*ServerEnvelope_CanvasResized
+	Payload   isServerEnvelope_Payload `protobuf_oneof:"Payload"`
+	RequestId string                   `protobuf:"bytes,13,opt,name=RequestId,proto3" json:"RequestId,omitempty"`
+}
+
+func (x *ServerEnvelope) Reset() {
+	*x = ServerEnvelope{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_definitions_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ServerEnvelope) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServerEnvelope) ProtoMessage() {}
+
+func (x *ServerEnvelope) ProtoReflect() protoreflect.Message {
+	mi := &file_definitions_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServerEnvelope.ProtoReflect.Descriptor instead.
+func (*ServerEnvelope) Descriptor() ([]byte, []int) {
+	return file_definitions_proto_rawDescGZIP(), []int{27}
+}
+
+func (m *ServerEnvelope) GetPayload() isServerEnvelope_Payload {
+	if m != nil {
+		return m.Payload
+	}
+	return nil
+}
+
+func (x *ServerEnvelope) GetConfig() *Config {
+	if x, ok := x.GetPayload().(*ServerEnvelope_Config); ok {
+		return x.Config
+	}
+	return nil
+}
+
+func (x *ServerEnvelope) GetCanvas() *CanvasSnapshot {
+	if x, ok := x.GetPayload().(*ServerEnvelope_Canvas); ok {
+		return x.Canvas
+	}
+	return nil
+}
+
+func (x *ServerEnvelope) GetPixel() *PixelInfo {
+	if x, ok := x.GetPayload().(*ServerEnvelope_Pixel); ok {
+		return x.Pixel
+	}
+	return nil
+}
+
+func (x *ServerEnvelope) GetCooldown() *Cooldown {
+	if x, ok := x.GetPayload().(*ServerEnvelope_Cooldown); ok {
+		return x.Cooldown
+	}
+	return nil
+}
+
+func (x *ServerEnvelope) GetLeaderboard() *LeaderboardPage {
+	if x, ok := x.GetPayload().(*ServerEnvelope_Leaderboard); ok {
+		return x.Leaderboard
+	}
+	return nil
+}
+
+func (x *ServerEnvelope) GetPlaced() *Placed {
+	if x, ok := x.GetPayload().(*ServerEnvelope_Placed); ok {
+		return x.Placed
+	}
+	return nil
+}
+
+func (x *ServerEnvelope) GetUpdate() *PixelUpdate {
+	if x, ok := x.GetPayload().(*ServerEnvelope_Update); ok {
+		return x.Update
+	}
+	return nil
+}
+
+func (x *ServerEnvelope) GetUpdateBatch() *PixelBatch {
+	if x, ok := x.GetPayload().(*ServerEnvelope_UpdateBatch); ok {
+		return x.UpdateBatch
+	}
+	return nil
+}
+
+func (x *ServerEnvelope) GetRejection() *Rejection {
+	if x, ok := x.GetPayload().(*ServerEnvelope_Rejection); ok {
+		return x.Rejection
+	}
+	return nil
+}
+
+func (x *ServerEnvelope) GetCredits() *CreditBalance {
+	if x, ok := x.GetPayload().(*ServerEnvelope_Credits); ok {
+		return x.Credits
+	}
+	return nil
+}
+
+func (x *ServerEnvelope) GetCanvasState() *CanvasPhase {
+	if x, ok := x.GetPayload().(*ServerEnvelope_CanvasState); ok {
+		return x.CanvasState
+	}
+	return nil
+}
+
+func (x *ServerEnvelope) GetCanvasResized() *CanvasResize {
+	if x, ok := x.GetPayload().(*ServerEnvelope_CanvasResized); ok {
+		return x.CanvasResized
+	}
+	return nil
+}
+
+func (x *ServerEnvelope) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+type isServerEnvelope_Payload interface {
+	isServerEnvelope_Payload()
+}
+
+type ServerEnvelope_Config struct {
+	Config *Config `protobuf:"bytes,1,opt,name=Config,proto3,oneof"`
+}
+
+type ServerEnvelope_Canvas struct {
+	Canvas *CanvasSnapshot `protobuf:"bytes,2,opt,name=Canvas,proto3,oneof"`
+}
+
+type ServerEnvelope_Pixel struct {
+	Pixel *PixelInfo `protobuf:"bytes,3,opt,name=Pixel,proto3,oneof"`
+}
+
+type ServerEnvelope_Cooldown struct {
+	Cooldown *Cooldown `protobuf:"bytes,4,opt,name=Cooldown,proto3,oneof"`
+}
+
+type ServerEnvelope_Leaderboard struct {
+	Leaderboard *LeaderboardPage `protobuf:"bytes,5,opt,name=Leaderboard,proto3,oneof"`
+}
+
+type ServerEnvelope_Placed struct {
+	Placed *Placed `protobuf:"bytes,6,opt,name=Placed,proto3,oneof"`
+}
+
+type ServerEnvelope_Update struct {
+	Update *PixelUpdate `protobuf:"bytes,7,opt,name=Update,proto3,oneof"`
+}
+
+type ServerEnvelope_UpdateBatch struct {
+	UpdateBatch *PixelBatch `protobuf:"bytes,8,opt,name=UpdateBatch,proto3,oneof"`
+}
+
+type ServerEnvelope_Rejection struct {
+	Rejection *Rejection `protobuf:"bytes,9,opt,name=Rejection,proto3,oneof"`
+}
+
+type ServerEnvelope_Credits struct {
+	Credits *CreditBalance `protobuf:"bytes,10,opt,name=Credits,proto3,oneof"`
+}
+
+type ServerEnvelope_CanvasState struct {
+	CanvasState *CanvasPhase `protobuf:"bytes,11,opt,name=CanvasState,proto3,oneof"`
+}
+
+type ServerEnvelope_CanvasResized struct {
+	CanvasResized *CanvasResize `protobuf:"bytes,12,opt,name=CanvasResized,proto3,oneof"`
+}
+
+func (*ServerEnvelope_Config) isServerEnvelope_Payload() {}
+
+func (*ServerEnvelope_Canvas) isServerEnvelope_Payload() {}
+
+func (*ServerEnvelope_Pixel) isServerEnvelope_Payload() {}
+
+func (*ServerEnvelope_Cooldown) isServerEnvelope_Payload() {}
+
+func (*ServerEnvelope_Leaderboard) isServerEnvelope_Payload() {}
+
+func (*ServerEnvelope_Placed) isServerEnvelope_Payload() {}
+
+func (*ServerEnvelope_Update) isServerEnvelope_Payload() {}
+
+func (*ServerEnvelope_UpdateBatch) isServerEnvelope_Payload() {}
+
+func (*ServerEnvelope_Rejection) isServerEnvelope_Payload() {}
+
+func (*ServerEnvelope_Credits) isServerEnvelope_Payload() {}
+
+func (*ServerEnvelope_CanvasState) isServerEnvelope_Payload() {}
+
+func (*ServerEnvelope_CanvasResized) isServerEnvelope_Payload() {}
+
+type CanvasRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CanvasIdentifier string `protobuf:"bytes,1,opt,name=CanvasIdentifier,proto3" json:"CanvasIdentifier,omitempty"`
+}
+
+func (x *CanvasRequest) Reset() {
+	*x = CanvasRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_definitions_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CanvasRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CanvasRequest) ProtoMessage() {}
+
+func (x *CanvasRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_definitions_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CanvasRequest.ProtoReflect.Descriptor instead.
+func (*CanvasRequest) Descriptor() ([]byte, []int) {
+	return file_definitions_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *CanvasRequest) GetCanvasIdentifier() string {
+	if x != nil {
+		return x.CanvasIdentifier
+	}
+	return ""
+}
+
+type RegionRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CanvasIdentifier string `protobuf:"bytes,1,opt,name=CanvasIdentifier,proto3" json:"CanvasIdentifier,omitempty"`
+	X                int32  `protobuf:"varint,2,opt,name=X,proto3" json:"X,omitempty"`
+	Y                int32  `protobuf:"varint,3,opt,name=Y,proto3" json:"Y,omitempty"`
+	Width            int32  `protobuf:"varint,4,opt,name=Width,proto3" json:"Width,omitempty"`
+	Height           int32  `protobuf:"varint,5,opt,name=Height,proto3" json:"Height,omitempty"`
+}
+
+func (x *RegionRequest) Reset() {
+	*x = RegionRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_definitions_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RegionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegionRequest) ProtoMessage() {}
+
+func (x *RegionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_definitions_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegionRequest.ProtoReflect.Descriptor instead.
+func (*RegionRequest) Descriptor() ([]byte, []int) {
+	return file_definitions_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *RegionRequest) GetCanvasIdentifier() string {
+	if x != nil {
+		return x.CanvasIdentifier
+	}
+	return ""
+}
+
+func (x *RegionRequest) GetX() int32 {
+	if x != nil {
+		return x.X
+	}
+	return 0
+}
+
+func (x *RegionRequest) GetY() int32 {
+	if x != nil {
+		return x.Y
+	}
+	return 0
+}
+
+func (x *RegionRequest) GetWidth() int32 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
+func (x *RegionRequest) GetHeight() int32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+type CanvasRegion struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	X      int32   `protobuf:"varint,1,opt,name=X,proto3" json:"X,omitempty"`
+	Y      int32   `protobuf:"varint,2,opt,name=Y,proto3" json:"Y,omitempty"`
+	Width  int32   `protobuf:"varint,3,opt,name=Width,proto3" json:"Width,omitempty"`
+	Height int32   `protobuf:"varint,4,opt,name=Height,proto3" json:"Height,omitempty"`
+	Pixels []int32 `protobuf:"varint,5,rep,packed,name=Pixels,proto3" json:"Pixels,omitempty"`
+}
+
+func (x *CanvasRegion) Reset() {
+	*x = CanvasRegion{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_definitions_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CanvasRegion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CanvasRegion) ProtoMessage() {}
+
+func (x *CanvasRegion) ProtoReflect() protoreflect.Message {
+	mi := &file_definitions_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CanvasRegion.ProtoReflect.Descriptor instead.
+func (*CanvasRegion) Descriptor() ([]byte, []int) {
+	return file_definitions_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *CanvasRegion) GetX() int32 {
+	if x != nil {
+		return x.X
+	}
+	return 0
+}
+
+func (x *CanvasRegion) GetY() int32 {
+	if x != nil {
+		return x.Y
+	}
+	return 0
+}
+
+func (x *CanvasRegion) GetWidth() int32 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
+func (x *CanvasRegion) GetHeight() int32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *CanvasRegion) GetPixels() []int32 {
+	if x != nil {
+		return x.Pixels
+	}
+	return nil
+}
+
+type PixelRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CanvasIdentifier string `protobuf:"bytes,1,opt,name=CanvasIdentifier,proto3" json:"CanvasIdentifier,omitempty"`
+	PixelId          int32  `protobuf:"varint,2,opt,name=PixelId,proto3" json:"PixelId,omitempty"`
+}
+
+func (x *PixelRequest) Reset() {
+	*x = PixelRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_definitions_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PixelRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PixelRequest) ProtoMessage() {}
+
+func (x *PixelRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_definitions_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PixelRequest.ProtoReflect.Descriptor instead.
+func (*PixelRequest) Descriptor() ([]byte, []int) {
+	return file_definitions_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *PixelRequest) GetCanvasIdentifier() string {
+	if x != nil {
+		return x.CanvasIdentifier
+	}
+	return ""
+}
+
+func (x *PixelRequest) GetPixelId() int32 {
+	if x != nil {
+		return x.PixelId
+	}
+	return 0
+}
+
+type PlaceRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CanvasIdentifier string `protobuf:"bytes,1,opt,name=CanvasIdentifier,proto3" json:"CanvasIdentifier,omitempty"`
+	// Types that are assignable to Placement:
+	//	*PlaceRequest_SetPixel
+	//	*PlaceRequest_SetPixels
+	Placement isPlaceRequest_Placement `protobuf_oneof:"Placement"`
+	RequestId string                   `protobuf:"bytes,4,opt,name=RequestId,proto3" json:"RequestId,omitempty"`
+}
+
+func (x *PlaceRequest) Reset() {
+	*x = PlaceRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_definitions_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PlaceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlaceRequest) ProtoMessage() {}
+
+func (x *PlaceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_definitions_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlaceRequest.ProtoReflect.Descriptor instead.
+func (*PlaceRequest) Descriptor() ([]byte, []int) {
+	return file_definitions_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *PlaceRequest) GetCanvasIdentifier() string {
+	if x != nil {
+		return x.CanvasIdentifier
+	}
+	return ""
+}
+
+func (m *PlaceRequest) GetPlacement() isPlaceRequest_Placement {
+	if m != nil {
+		return m.Placement
+	}
+	return nil
+}
+
+func (x *PlaceRequest) GetSetPixel() *SetPixelRequest {
+	if x, ok := x.GetPlacement().(*PlaceRequest_SetPixel); ok {
+		return x.SetPixel
+	}
+	return nil
+}
+
+func (x *PlaceRequest) GetSetPixels() *SetPixelsRequest {
+	if x, ok := x.GetPlacement().(*PlaceRequest_SetPixels); ok {
+		return x.SetPixels
+	}
+	return nil
+}
+
+func (x *PlaceRequest) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+type isPlaceRequest_Placement interface {
+	isPlaceRequest_Placement()
+}
+
+type PlaceRequest_SetPixel struct {
+	SetPixel *SetPixelRequest `protobuf:"bytes,2,opt,name=SetPixel,proto3,oneof"`
+}
+
+type PlaceRequest_SetPixels struct {
+	SetPixels *SetPixelsRequest `protobuf:"bytes,3,opt,name=SetPixels,proto3,oneof"`
+}
+
+func (*PlaceRequest_SetPixel) isPlaceRequest_Placement() {}
+
+func (*PlaceRequest_SetPixels) isPlaceRequest_Placement() {}
+
+type PlaceResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Placed *Placed `protobuf:"bytes,1,opt,name=Placed,proto3" json:"Placed,omitempty"`
+	// Balance after the placement on canvases that use credits
+	Credits *CreditBalance `protobuf:"bytes,2,opt,name=Credits,proto3" json:"Credits,omitempty"`
+}
+
+func (x *PlaceResponse) Reset() {
+	*x = PlaceResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_definitions_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PlaceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlaceResponse) ProtoMessage() {}
+
+func (x *PlaceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_definitions_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlaceResponse.ProtoReflect.Descriptor instead.
+func (*PlaceResponse) Descriptor() ([]byte, []int) {
+	return file_definitions_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *PlaceResponse) GetPlaced() *Placed {
+	if x != nil {
+		return x.Placed
+	}
+	return nil
+}
+
+func (x *PlaceResponse) GetCredits() *CreditBalance {
+	if x != nil {
+		return x.Credits
+	}
+	return nil
+}
+
+type StreamRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CanvasIdentifier string `protobuf:"bytes,1,opt,name=CanvasIdentifier,proto3" json:"CanvasIdentifier,omitempty"`
+	// Resume after this update, the UpdateId of the last update received
+	LastUpdateId string `protobuf:"bytes,2,opt,name=LastUpdateId,proto3" json:"LastUpdateId,omitempty"`
+	// Start with a snapshot of the whole canvas, also sent when the updates to resume from are gone
+	Snapshot bool `protobuf:"varint,3,opt,name=Snapshot,proto3" json:"Snapshot,omitempty"`
+}
+
+func (x *StreamRequest) Reset() {
+	*x = StreamRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_definitions_proto_msgTypes[34]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StreamRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamRequest) ProtoMessage() {}
+
+func (x *StreamRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_definitions_proto_msgTypes[34]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamRequest.ProtoReflect.Descriptor instead.
+func (*StreamRequest) Descriptor() ([]byte, []int) {
+	return file_definitions_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *StreamRequest) GetCanvasIdentifier() string {
+	if x != nil {
+		return x.CanvasIdentifier
+	}
+	return ""
+}
+
+func (x *StreamRequest) GetLastUpdateId() string {
+	if x != nil {
+		return x.LastUpdateId
+	}
+	return ""
+}
+
+func (x *StreamRequest) GetSnapshot() bool {
+	if x != nil {
+		return x.Snapshot
+	}
+	return false
+}
+
+type CanvasUpdate struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UpdateId string          `protobuf:"bytes,1,opt,name=UpdateId,proto3" json:"UpdateId,omitempty"`
+	Event    *ServerEnvelope `protobuf:"bytes,2,opt,name=Event,proto3" json:"Event,omitempty"`
+}
+
+func (x *CanvasUpdate) Reset() {
+	*x = CanvasUpdate{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_definitions_proto_msgTypes[35]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CanvasUpdate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CanvasUpdate) ProtoMessage() {}
+
+func (x *CanvasUpdate) ProtoReflect() protoreflect.Message {
+	mi := &file_definitions_proto_msgTypes[35]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CanvasUpdate.ProtoReflect.Descriptor instead.
+func (*CanvasUpdate) Descriptor() ([]byte, []int) {
+	return file_definitions_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *CanvasUpdate) GetUpdateId() string {
+	if x != nil {
+		return x.UpdateId
+	}
+	return ""
+}
+
+func (x *CanvasUpdate) GetEvent() *ServerEnvelope {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+var File_definitions_proto protoreflect.FileDescriptor
+
+var file_definitions_proto_rawDesc = []byte{
+	0x0a, 0x11, 0x64, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x22, 0x8c, 0x03, 0x0a, 0x0e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x4d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x54, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x4d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x50, 0x69, 0x78, 0x65,
+	0x6c, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x50, 0x69, 0x78, 0x65, 0x6c,
+	0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x05, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x12, 0x2c, 0x0a, 0x11, 0x4c, 0x65, 0x61, 0x64,
+	0x65, 0x72, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x57, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x11, 0x4c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x62, 0x6f, 0x61, 0x72, 0x64,
+	0x57, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x12, 0x16, 0x0a, 0x06, 0x4f, 0x66, 0x66, 0x73, 0x65, 0x74,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x4f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x14,
+	0x0a, 0x05, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x4c,
+	0x69, 0x6d, 0x69, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x50, 0x69, 0x78, 0x65, 0x6c, 0x49, 0x64, 0x73,
+	0x18, 0x07, 0x20, 0x03, 0x28, 0x05, 0x52, 0x08, 0x50, 0x69, 0x78, 0x65, 0x6c, 0x49, 0x64, 0x73,
+	0x12, 0x14, 0x0a, 0x05, 0x42, 0x72, 0x75, 0x73, 0x68, 0x18, 0x08, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x05, 0x42, 0x72, 0x75, 0x73, 0x68, 0x12, 0x1c, 0x0a, 0x09, 0x54, 0x6f, 0x50, 0x69, 0x78, 0x65,
+	0x6c, 0x49, 0x64, 0x18, 0x09, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x54, 0x6f, 0x50, 0x69, 0x78,
+	0x65, 0x6c, 0x49, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x42, 0x72, 0x75, 0x73, 0x68, 0x53, 0x69, 0x7a,
+	0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x42, 0x72, 0x75, 0x73, 0x68, 0x53, 0x69,
+	0x7a, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x64, 0x18,
+	0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x64,
+	0x12, 0x2a, 0x0a, 0x10, 0x43, 0x61, 0x6e, 0x76, 0x61, 0x73, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69,
+	0x66, 0x69, 0x65, 0x72, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x43, 0x61, 0x6e, 0x76,
+	0x61, 0x73, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x12, 0x14, 0x0a, 0x05,
+	0x57, 0x61, 0x74, 0x63, 0x68, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x57, 0x61, 0x74,
+	0x63, 0x68, 0x22, 0x4f, 0x0a, 0x05, 0x50, 0x69, 0x78, 0x65, 0x6c, 0x12, 0x18, 0x0a, 0x07, 0x50,
+	0x69, 0x78, 0x65, 0x6c, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x50, 0x69,
+	0x78, 0x65, 0x6c, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x55,
+	0x73, 0x65, 0x72, 0x49, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x55, 0x73, 0x65,
+	0x72, 0x49, 0x64, 0x22, 0x54, 0x0a, 0x10, 0x4c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x62, 0x6f, 0x61,
+	0x72, 0x64, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x52, 0x61, 0x6e, 0x6b, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x52, 0x61, 0x6e, 0x6b, 0x12, 0x16, 0x0a, 0x06, 0x55,
+	0x73, 0x65, 0x72, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x55, 0x73, 0x65,
+	0x72, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x05, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x22, 0x4a, 0x0a, 0x0c, 0x50, 0x61, 0x6c,
+	0x65, 0x74, 0x74, 0x65, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x43, 0x6f, 0x6c,
+	0x6f, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x12,
+	0x10, 0x0a, 0x03, 0x48, 0x65, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x48, 0x65,
+	0x78, 0x12, 0x12, 0x0a, 0x04, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x4e, 0x61, 0x6d, 0x65, 0x22, 0xb0, 0x08, 0x0a, 0x0f, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x4d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x54, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b,
+	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x4d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x4d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x43, 0x61, 0x6e, 0x76, 0x61, 0x73, 0x18,
+	0x03, 0x20, 0x03, 0x28, 0x05, 0x52, 0x06, 0x43, 0x61, 0x6e, 0x76, 0x61, 0x73, 0x12, 0x16, 0x0a,
+	0x06, 0x55, 0x73, 0x65, 0x72, 0x49, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x55,
+	0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x50, 0x69, 0x78, 0x65, 0x6c, 0x49, 0x64,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x50, 0x69, 0x78, 0x65, 0x6c, 0x49, 0x64, 0x12,
+	0x14, 0x0a, 0x05, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05,
+	0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x12, 0x1c, 0x0a, 0x09, 0x54, 0x69, 0x6d, 0x65, 0x53, 0x74, 0x61,
+	0x6d, 0x70, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x54, 0x69, 0x6d, 0x65, 0x53, 0x74,
+	0x61, 0x6d, 0x70, 0x12, 0x20, 0x0a, 0x0b, 0x43, 0x61, 0x6e, 0x76, 0x61, 0x73, 0x57, 0x69, 0x64,
+	0x74, 0x68, 0x18, 0x08, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x43, 0x61, 0x6e, 0x76, 0x61, 0x73,
+	0x57, 0x69, 0x64, 0x74, 0x68, 0x12, 0x22, 0x0a, 0x0c, 0x43, 0x61, 0x6e, 0x76, 0x61, 0x73, 0x48,
+	0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x43, 0x61, 0x6e,
+	0x76, 0x61, 0x73, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x22, 0x0a, 0x0c, 0x55, 0x73, 0x65,
+	0x72, 0x43, 0x6f, 0x6f, 0x6c, 0x64, 0x6f, 0x77, 0x6e, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x0c, 0x55, 0x73, 0x65, 0x72, 0x43, 0x6f, 0x6f, 0x6c, 0x64, 0x6f, 0x77, 0x6e, 0x12, 0x24, 0x0a,
+	0x0d, 0x50, 0x69, 0x78, 0x65, 0x6c, 0x43, 0x6f, 0x6f, 0x6c, 0x64, 0x6f, 0x77, 0x6e, 0x18, 0x0b,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x0d, 0x50, 0x69, 0x78, 0x65, 0x6c, 0x43, 0x6f, 0x6f, 0x6c, 0x64,
+	0x6f, 0x77, 0x6e, 0x12, 0x22, 0x0a, 0x0c, 0x50, 0x69, 0x6e, 0x67, 0x49, 0x6e, 0x74, 0x65, 0x72,
+	0x76, 0x61, 0x6c, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x50, 0x69, 0x6e, 0x67, 0x49,
+	0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x12, 0x2c, 0x0a, 0x11, 0x44, 0x69, 0x73, 0x63, 0x6f,
+	0x6e, 0x6e, 0x65, 0x63, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18, 0x0d, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x11, 0x44, 0x69, 0x73, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x54, 0x69,
+	0x6d, 0x65, 0x6f, 0x75, 0x74, 0x12, 0x22, 0x0a, 0x0c, 0x52, 0x65, 0x74, 0x72, 0x79, 0x41, 0x66,
+	0x74, 0x65, 0x72, 0x4d, 0x73, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x52, 0x65, 0x74,
+	0x72, 0x79, 0x41, 0x66, 0x74, 0x65, 0x72, 0x4d, 0x73, 0x12, 0x28, 0x0a, 0x0f, 0x50, 0x69, 0x78,
+	0x65, 0x6c, 0x73, 0x41, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x0f, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x0f, 0x50, 0x69, 0x78, 0x65, 0x6c, 0x73, 0x41, 0x76, 0x61, 0x69, 0x6c, 0x61,
+	0x62, 0x6c, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x4e, 0x65, 0x78, 0x74, 0x50, 0x69, 0x78, 0x65, 0x6c,
+	0x41, 0x74, 0x18, 0x10, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x4e, 0x65, 0x78, 0x74, 0x50, 0x69,
+	0x78, 0x65, 0x6c, 0x41, 0x74, 0x12, 0x38, 0x0a, 0x17, 0x55, 0x73, 0x65, 0x72, 0x43, 0x6f, 0x6f,
+	0x6c, 0x64, 0x6f, 0x77, 0x6e, 0x52, 0x65, 0x6d, 0x61, 0x69, 0x6e, 0x69, 0x6e, 0x67, 0x4d, 0x73,
+	0x18, 0x11, 0x20, 0x01, 0x28, 0x03, 0x52, 0x17, 0x55, 0x73, 0x65, 0x72, 0x43, 0x6f, 0x6f, 0x6c,
+	0x64, 0x6f, 0x77, 0x6e, 0x52, 0x65, 0x6d, 0x61, 0x69, 0x6e, 0x69, 0x6e, 0x67, 0x4d, 0x73, 0x12,
+	0x3a, 0x0a, 0x18, 0x50, 0x69, 0x78, 0x65, 0x6c, 0x43, 0x6f, 0x6f, 0x6c, 0x64, 0x6f, 0x77, 0x6e,
+	0x52, 0x65, 0x6d, 0x61, 0x69, 0x6e, 0x69, 0x6e, 0x67, 0x4d, 0x73, 0x18, 0x12, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x18, 0x50, 0x69, 0x78, 0x65, 0x6c, 0x43, 0x6f, 0x6f, 0x6c, 0x64, 0x6f, 0x77, 0x6e,
+	0x52, 0x65, 0x6d, 0x61, 0x69, 0x6e, 0x69, 0x6e, 0x67, 0x4d, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x43,
+	0x61, 0x6e, 0x76, 0x61, 0x73, 0x53, 0x74, 0x61, 0x74, 0x65, 0x18, 0x13, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0b, 0x43, 0x61, 0x6e, 0x76, 0x61, 0x73, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x24, 0x0a,
+	0x0d, 0x53, 0x74, 0x61, 0x74, 0x65, 0x53, 0x74, 0x61, 0x72, 0x74, 0x73, 0x41, 0x74, 0x18, 0x14,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x53, 0x74, 0x61, 0x74, 0x65, 0x53, 0x74, 0x61, 0x72, 0x74,
+	0x73, 0x41, 0x74, 0x12, 0x20, 0x0a, 0x0b, 0x53, 0x74, 0x61, 0x74, 0x65, 0x45, 0x6e, 0x64, 0x73,
+	0x41, 0x74, 0x18, 0x15, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x53, 0x74, 0x61, 0x74, 0x65, 0x45,
+	0x6e, 0x64, 0x73, 0x41, 0x74, 0x12, 0x2a, 0x0a, 0x10, 0x43, 0x61, 0x6e, 0x76, 0x61, 0x73, 0x49,
+	0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x18, 0x16, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x10, 0x43, 0x61, 0x6e, 0x76, 0x61, 0x73, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65,
+	0x72, 0x12, 0x33, 0x0a, 0x0b, 0x4c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x62, 0x6f, 0x61, 0x72, 0x64,
+	0x18, 0x17, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x4c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x62,
+	0x6f, 0x61, 0x72, 0x64, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0b, 0x4c, 0x65, 0x61, 0x64, 0x65,
+	0x72, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x12, 0x2a, 0x0a, 0x10, 0x4c, 0x65, 0x61, 0x64, 0x65, 0x72,
+	0x62, 0x6f, 0x61, 0x72, 0x64, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x18, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x10, 0x4c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x54, 0x6f, 0x74,
+	0x61, 0x6c, 0x12, 0x27, 0x0a, 0x07, 0x50, 0x61, 0x6c, 0x65, 0x74, 0x74, 0x65, 0x18, 0x19, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x50, 0x61, 0x6c, 0x65, 0x74, 0x74, 0x65, 0x43, 0x6f, 0x6c,
+	0x6f, 0x72, 0x52, 0x07, 0x50, 0x61, 0x6c, 0x65, 0x74, 0x74, 0x65, 0x12, 0x1e, 0x0a, 0x06, 0x50,
+	0x69, 0x78, 0x65, 0x6c, 0x73, 0x18, 0x1a, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x06, 0x2e, 0x50, 0x69,
+	0x78, 0x65, 0x6c, 0x52, 0x06, 0x50, 0x69, 0x78, 0x65, 0x6c, 0x73, 0x12, 0x1f, 0x0a, 0x06, 0x52,
+	0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x1b, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x07, 0x2e, 0x52, 0x65,
+	0x61, 0x73, 0x6f, 0x6e, 0x52, 0x06, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x1c, 0x0a, 0x09,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x64, 0x18, 0x1c, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x53, 0x65,
+	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x18, 0x1d, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x53,
+	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x22, 0x3e, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x43,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2a, 0x0a, 0x10,
+	0x43, 0x61, 0x6e, 0x76, 0x61, 0x73, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x43, 0x61, 0x6e, 0x76, 0x61, 0x73, 0x49, 0x64,
+	0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x22, 0x3e, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x43,
+	0x61, 0x6e, 0x76, 0x61, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2a, 0x0a, 0x10,
+	0x43, 0x61, 0x6e, 0x76, 0x61, 0x73, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x43, 0x61, 0x6e, 0x76, 0x61, 0x73, 0x49, 0x64,
+	0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x22, 0x41, 0x0a, 0x0f, 0x53, 0x65, 0x74, 0x50,
+	0x69, 0x78, 0x65, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x50,
+	0x69, 0x78, 0x65, 0x6c, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x50, 0x69,
+	0x78, 0x65, 0x6c, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x22, 0x2c, 0x0a, 0x10, 0x56,
+	0x69, 0x65, 0x77, 0x50, 0x69, 0x78, 0x65, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x18, 0x0a, 0x07, 0x50, 0x69, 0x78, 0x65, 0x6c, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x07, 0x50, 0x69, 0x78, 0x65, 0x6c, 0x49, 0x64, 0x22, 0x2e, 0x0a, 0x12, 0x47, 0x65, 0x74,
+	0x43, 0x6f, 0x6f, 0x6c, 0x64, 0x6f, 0x77, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x18, 0x0a, 0x07, 0x50, 0x69, 0x78, 0x65, 0x6c, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x07, 0x50, 0x69, 0x78, 0x65, 0x6c, 0x49, 0x64, 0x22, 0x5d, 0x0a, 0x15, 0x47, 0x65, 0x74,
+	0x4c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x57, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x57, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x12, 0x16, 0x0a, 0x06, 0x4f, 0x66,
+	0x66, 0x73, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x4f, 0x66, 0x66, 0x73,
+	0x65, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x05, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x22, 0xb0, 0x01, 0x0a, 0x10, 0x53, 0x65, 0x74,
+	0x50, 0x69, 0x78, 0x65, 0x6c, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a,
+	0x05, 0x42, 0x72, 0x75, 0x73, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x42, 0x72,
+	0x75, 0x73, 0x68, 0x12, 0x1a, 0x0a, 0x08, 0x50, 0x69, 0x78, 0x65, 0x6c, 0x49, 0x64, 0x73, 0x18,
+	0x02, 0x20, 0x03, 0x28, 0x05, 0x52, 0x08, 0x50, 0x69, 0x78, 0x65, 0x6c, 0x49, 0x64, 0x73, 0x12,
+	0x18, 0x0a, 0x07, 0x50, 0x69, 0x78, 0x65, 0x6c, 0x49, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x07, 0x50, 0x69, 0x78, 0x65, 0x6c, 0x49, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x54, 0x6f, 0x50,
+	0x69, 0x78, 0x65, 0x6c, 0x49, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x54, 0x6f,
+	0x50, 0x69, 0x78, 0x65, 0x6c, 0x49, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x42, 0x72, 0x75, 0x73, 0x68,
+	0x53, 0x69, 0x7a, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x42, 0x72, 0x75, 0x73,
+	0x68, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x22, 0x16, 0x0a, 0x14, 0x55,
+	0x6e, 0x64, 0x6f, 0x50, 0x6c, 0x61, 0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x22, 0x55, 0x0a, 0x11, 0x4a, 0x6f, 0x69, 0x6e, 0x43, 0x61, 0x6e, 0x76, 0x61,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2a, 0x0a, 0x10, 0x43, 0x61, 0x6e, 0x76,
+	0x61, 0x73, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x10, 0x43, 0x61, 0x6e, 0x76, 0x61, 0x73, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69,
+	0x66, 0x69, 0x65, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x57, 0x61, 0x74, 0x63, 0x68, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x05, 0x57, 0x61, 0x74, 0x63, 0x68, 0x22, 0xa5, 0x04, 0x0a, 0x0e, 0x43,
+	0x6c, 0x69, 0x65, 0x6e, 0x74, 0x45, 0x6e, 0x76, 0x65, 0x6c, 0x6f, 0x70, 0x65, 0x12, 0x31, 0x0a,
+	0x09, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x11, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x09, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67,
+	0x12, 0x31, 0x0a, 0x09, 0x47, 0x65, 0x74, 0x43, 0x61, 0x6e, 0x76, 0x61, 0x73, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x61, 0x6e, 0x76, 0x61, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x09, 0x47, 0x65, 0x74, 0x43, 0x61, 0x6e,
+	0x76, 0x61, 0x73, 0x12, 0x2e, 0x0a, 0x08, 0x53, 0x65, 0x74, 0x50, 0x69, 0x78, 0x65, 0x6c, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x53, 0x65, 0x74, 0x50, 0x69, 0x78, 0x65, 0x6c,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x08, 0x53, 0x65, 0x74, 0x50, 0x69,
+	0x78, 0x65, 0x6c, 0x12, 0x31, 0x0a, 0x09, 0x56, 0x69, 0x65, 0x77, 0x50, 0x69, 0x78, 0x65, 0x6c,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x56, 0x69, 0x65, 0x77, 0x50, 0x69, 0x78,
+	0x65, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x09, 0x56, 0x69, 0x65,
+	0x77, 0x50, 0x69, 0x78, 0x65, 0x6c, 0x12, 0x37, 0x0a, 0x0b, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6f,
+	0x6c, 0x64, 0x6f, 0x77, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x47, 0x65,
+	0x74, 0x43, 0x6f, 0x6f, 0x6c, 0x64, 0x6f, 0x77, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x48, 0x00, 0x52, 0x0b, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6f, 0x6c, 0x64, 0x6f, 0x77, 0x6e, 0x12,
+	0x40, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x4c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x62, 0x6f, 0x61, 0x72,
+	0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x47, 0x65, 0x74, 0x4c, 0x65, 0x61,
+	0x64, 0x65, 0x72, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48,
+	0x00, 0x52, 0x0e, 0x47, 0x65, 0x74, 0x4c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x62, 0x6f, 0x61, 0x72,
+	0x64, 0x12, 0x31, 0x0a, 0x09, 0x53, 0x65, 0x74, 0x50, 0x69, 0x78, 0x65, 0x6c, 0x73, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x53, 0x65, 0x74, 0x50, 0x69, 0x78, 0x65, 0x6c, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x09, 0x53, 0x65, 0x74, 0x50, 0x69,
+	0x78, 0x65, 0x6c, 0x73, 0x12, 0x3d, 0x0a, 0x0d, 0x55, 0x6e, 0x64, 0x6f, 0x50, 0x6c, 0x61, 0x63,
+	0x65, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x55, 0x6e,
+	0x64, 0x6f, 0x50, 0x6c, 0x61, 0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x48, 0x00, 0x52, 0x0d, 0x55, 0x6e, 0x64, 0x6f, 0x50, 0x6c, 0x61, 0x63, 0x65, 0x6d,
+	0x65, 0x6e, 0x74, 0x12, 0x34, 0x0a, 0x0a, 0x4a, 0x6f, 0x69, 0x6e, 0x43, 0x61, 0x6e, 0x76, 0x61,
+	0x73, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x4a, 0x6f, 0x69, 0x6e, 0x43, 0x61,
+	0x6e, 0x76, 0x61, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x0a, 0x4a,
+	0x6f, 0x69, 0x6e, 0x43, 0x61, 0x6e, 0x76, 0x61, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x49, 0x64, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x64, 0x42, 0x09, 0x0a, 0x07, 0x50, 0x61, 0x79, 0x6c, 0x6f,
+	0x61, 0x64, 0x22, 0xaf, 0x03, 0x0a, 0x06, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x20, 0x0a,
+	0x0b, 0x43, 0x61, 0x6e, 0x76, 0x61, 0x73, 0x57, 0x69, 0x64, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x0b, 0x43, 0x61, 0x6e, 0x76, 0x61, 0x73, 0x57, 0x69, 0x64, 0x74, 0x68, 0x12,
+	0x22, 0x0a, 0x0c, 0x43, 0x61, 0x6e, 0x76, 0x61, 0x73, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x43, 0x61, 0x6e, 0x76, 0x61, 0x73, 0x48, 0x65, 0x69,
+	0x67, 0x68, 0x74, 0x12, 0x22, 0x0a, 0x0c, 0x55, 0x73, 0x65, 0x72, 0x43, 0x6f, 0x6f, 0x6c, 0x64,
+	0x6f, 0x77, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x55, 0x73, 0x65, 0x72, 0x43,
+	0x6f, 0x6f, 0x6c, 0x64, 0x6f, 0x77, 0x6e, 0x12, 0x24, 0x0a, 0x0d, 0x50, 0x69, 0x78, 0x65, 0x6c,
+	0x43, 0x6f, 0x6f, 0x6c, 0x64, 0x6f, 0x77, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0d,
+	0x50, 0x69, 0x78, 0x65, 0x6c, 0x43, 0x6f, 0x6f, 0x6c, 0x64, 0x6f, 0x77, 0x6e, 0x12, 0x22, 0x0a,
+	0x0c, 0x50, 0x69, 0x6e, 0x67, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x0c, 0x50, 0x69, 0x6e, 0x67, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61,
+	0x6c, 0x12, 0x2c, 0x0a, 0x11, 0x44, 0x69, 0x73, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x54,
+	0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x11, 0x44, 0x69,
+	0x73, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x12,
+	0x22, 0x0a, 0x05, 0x50, 0x68, 0x61, 0x73, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c,
+	0x2e, 0x43, 0x61, 0x6e, 0x76, 0x61, 0x73, 0x50, 0x68, 0x61, 0x73, 0x65, 0x52, 0x05, 0x50, 0x68,
+	0x61, 0x73, 0x65, 0x12, 0x28, 0x0a, 0x0f, 0x50, 0x69, 0x78, 0x65, 0x6c, 0x73, 0x41, 0x76, 0x61,
+	0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0f, 0x50, 0x69,
+	0x78, 0x65, 0x6c, 0x73, 0x41, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x20, 0x0a,
+	0x0b, 0x4e, 0x65, 0x78, 0x74, 0x50, 0x69, 0x78, 0x65, 0x6c, 0x41, 0x74, 0x18, 0x09, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x0b, 0x4e, 0x65, 0x78, 0x74, 0x50, 0x69, 0x78, 0x65, 0x6c, 0x41, 0x74, 0x12,
+	0x27, 0x0a, 0x07, 0x50, 0x61, 0x6c, 0x65, 0x74, 0x74, 0x65, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x0d, 0x2e, 0x50, 0x61, 0x6c, 0x65, 0x74, 0x74, 0x65, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x52,
+	0x07, 0x50, 0x61, 0x6c, 0x65, 0x74, 0x74, 0x65, 0x12, 0x2a, 0x0a, 0x10, 0x43, 0x61, 0x6e, 0x76,
+	0x61, 0x73, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x18, 0x0b, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x10, 0x43, 0x61, 0x6e, 0x76, 0x61, 0x73, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69,
+	0x66, 0x69, 0x65, 0x72, 0x22, 0x54, 0x0a, 0x0e, 0x43, 0x61, 0x6e, 0x76, 0x61, 0x73, 0x53, 0x6e,
+	0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x43, 0x61, 0x6e, 0x76, 0x61, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x05, 0x52, 0x06, 0x43, 0x61, 0x6e, 0x76, 0x61, 0x73, 0x12, 0x2a,
+	0x0a, 0x10, 0x43, 0x61, 0x6e, 0x76, 0x61, 0x73, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69,
+	0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x43, 0x61, 0x6e, 0x76, 0x61, 0x73,
+	0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x22, 0x53, 0x0a, 0x09, 0x50, 0x69,
+	0x78, 0x65, 0x6c, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x18, 0x0a, 0x07, 0x50, 0x69, 0x78, 0x65, 0x6c,
+	0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x50, 0x69, 0x78, 0x65, 0x6c, 0x49,
+	0x64, 0x12, 0x14, 0x0a, 0x05, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x05, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x55, 0x73, 0x65, 0x72, 0x49,
+	0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x55, 0x73, 0x65, 0x72, 0x49, 0x64, 0x22,
+	0xe6, 0x01, 0x0a, 0x08, 0x43, 0x6f, 0x6f, 0x6c, 0x64, 0x6f, 0x77, 0x6e, 0x12, 0x18, 0x0a, 0x07,
+	0x50, 0x69, 0x78, 0x65, 0x6c, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x50,
+	0x69, 0x78, 0x65, 0x6c, 0x49, 0x64, 0x12, 0x38, 0x0a, 0x17, 0x55, 0x73, 0x65, 0x72, 0x43, 0x6f,
+	0x6f, 0x6c, 0x64, 0x6f, 0x77, 0x6e, 0x52, 0x65, 0x6d, 0x61, 0x69, 0x6e, 0x69, 0x6e, 0x67, 0x4d,
+	0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x17, 0x55, 0x73, 0x65, 0x72, 0x43, 0x6f, 0x6f,
+	0x6c, 0x64, 0x6f, 0x77, 0x6e, 0x52, 0x65, 0x6d, 0x61, 0x69, 0x6e, 0x69, 0x6e, 0x67, 0x4d, 0x73,
+	0x12, 0x3a, 0x0a, 0x18, 0x50, 0x69, 0x78, 0x65, 0x6c, 0x43, 0x6f, 0x6f, 0x6c, 0x64, 0x6f, 0x77,
+	0x6e, 0x52, 0x65, 0x6d, 0x61, 0x69, 0x6e, 0x69, 0x6e, 0x67, 0x4d, 0x73, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x18, 0x50, 0x69, 0x78, 0x65, 0x6c, 0x43, 0x6f, 0x6f, 0x6c, 0x64, 0x6f, 0x77,
+	0x6e, 0x52, 0x65, 0x6d, 0x61, 0x69, 0x6e, 0x69, 0x6e, 0x67, 0x4d, 0x73, 0x12, 0x28, 0x0a, 0x0f,
+	0x50, 0x69, 0x78, 0x65, 0x6c, 0x73, 0x41, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0f, 0x50, 0x69, 0x78, 0x65, 0x6c, 0x73, 0x41, 0x76, 0x61,
+	0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x4e, 0x65, 0x78, 0x74, 0x50, 0x69,
+	0x78, 0x65, 0x6c, 0x41, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x4e, 0x65, 0x78,
+	0x74, 0x50, 0x69, 0x78, 0x65, 0x6c, 0x41, 0x74, 0x22, 0x54, 0x0a, 0x0f, 0x4c, 0x65, 0x61, 0x64,
+	0x65, 0x72, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x50, 0x61, 0x67, 0x65, 0x12, 0x2b, 0x0a, 0x07, 0x45,
+	0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x4c,
+	0x65, 0x61, 0x64, 0x65, 0x72, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52,
+	0x07, 0x45, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x54, 0x6f, 0x74, 0x61,
+	0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x22, 0x6e,
+	0x0a, 0x06, 0x50, 0x6c, 0x61, 0x63, 0x65, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x4d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x4d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x12, 0x1e, 0x0a, 0x06, 0x50, 0x69, 0x78, 0x65, 0x6c, 0x73, 0x18, 0x02, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x06, 0x2e, 0x50, 0x69, 0x78, 0x65, 0x6c, 0x52, 0x06, 0x50, 0x69, 0x78, 0x65,
+	0x6c, 0x73, 0x12, 0x2a, 0x0a, 0x10, 0x43, 0x61, 0x6e, 0x76, 0x61, 0x73, 0x49, 0x64, 0x65, 0x6e,
+	0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x43, 0x61,
+	0x6e, 0x76, 0x61, 0x73, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x22, 0x81,
+	0x01, 0x0a, 0x0b, 0x50, 0x69, 0x78, 0x65, 0x6c, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x16,
+	0x0a, 0x06, 0x55, 0x73, 0x65, 0x72, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x55, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x50, 0x69, 0x78, 0x65, 0x6c, 0x49,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x50, 0x69, 0x78, 0x65, 0x6c, 0x49, 0x64,
+	0x12, 0x14, 0x0a, 0x05, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x05, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x12, 0x2a, 0x0a, 0x10, 0x43, 0x61, 0x6e, 0x76, 0x61, 0x73,
+	0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x10, 0x43, 0x61, 0x6e, 0x76, 0x61, 0x73, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69,
+	0x65, 0x72, 0x22, 0x70, 0x0a, 0x0a, 0x50, 0x69, 0x78, 0x65, 0x6c, 0x42, 0x61, 0x74, 0x63, 0x68,
+	0x12, 0x16, 0x0a, 0x06, 0x55, 0x73, 0x65, 0x72, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x55, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x1e, 0x0a, 0x06, 0x50, 0x69, 0x78, 0x65,
+	0x6c, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x06, 0x2e, 0x50, 0x69, 0x78, 0x65, 0x6c,
+	0x52, 0x06, 0x50, 0x69, 0x78, 0x65, 0x6c, 0x73, 0x12, 0x2a, 0x0a, 0x10, 0x43, 0x61, 0x6e, 0x76,
+	0x61, 0x73, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x10, 0x43, 0x61, 0x6e, 0x76, 0x61, 0x73, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69,
+	0x66, 0x69, 0x65, 0x72, 0x22, 0x80, 0x03, 0x0a, 0x09, 0x52, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x1f, 0x0a, 0x06, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x07, 0x2e, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x52, 0x06, 0x52, 0x65, 0x61,
+	0x73, 0x6f, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x18, 0x0a,
+	0x07, 0x50, 0x69, 0x78, 0x65, 0x6c, 0x49, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07,
+	0x50, 0x69, 0x78, 0x65, 0x6c, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x43, 0x6f, 0x6c, 0x6f, 0x72,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x12, 0x22, 0x0a,
+	0x0c, 0x52, 0x65, 0x74, 0x72, 0x79, 0x41, 0x66, 0x74, 0x65, 0x72, 0x4d, 0x73, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x0c, 0x52, 0x65, 0x74, 0x72, 0x79, 0x41, 0x66, 0x74, 0x65, 0x72, 0x4d,
+	0x73, 0x12, 0x38, 0x0a, 0x17, 0x55, 0x73, 0x65, 0x72, 0x43, 0x6f, 0x6f, 0x6c, 0x64, 0x6f, 0x77,
+	0x6e, 0x52, 0x65, 0x6d, 0x61, 0x69, 0x6e, 0x69, 0x6e, 0x67, 0x4d, 0x73, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x17, 0x55, 0x73, 0x65, 0x72, 0x43, 0x6f, 0x6f, 0x6c, 0x64, 0x6f, 0x77, 0x6e,
+	0x52, 0x65, 0x6d, 0x61, 0x69, 0x6e, 0x69, 0x6e, 0x67, 0x4d, 0x73, 0x12, 0x3a, 0x0a, 0x18, 0x50,
+	0x69, 0x78, 0x65, 0x6c, 0x43, 0x6f, 0x6f, 0x6c, 0x64, 0x6f, 0x77, 0x6e, 0x52, 0x65, 0x6d, 0x61,
+	0x69, 0x6e, 0x69, 0x6e, 0x67, 0x4d, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x18, 0x50,
+	0x69, 0x78, 0x65, 0x6c, 0x43, 0x6f, 0x6f, 0x6c, 0x64, 0x6f, 0x77, 0x6e, 0x52, 0x65, 0x6d, 0x61,
+	0x69, 0x6e, 0x69, 0x6e, 0x67, 0x4d, 0x73, 0x12, 0x28, 0x0a, 0x0f, 0x50, 0x69, 0x78, 0x65, 0x6c,
+	0x73, 0x41, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x0f, 0x50, 0x69, 0x78, 0x65, 0x6c, 0x73, 0x41, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c,
+	0x65, 0x12, 0x20, 0x0a, 0x0b, 0x4e, 0x65, 0x78, 0x74, 0x50, 0x69, 0x78, 0x65, 0x6c, 0x41, 0x74,
+	0x18, 0x09, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x4e, 0x65, 0x78, 0x74, 0x50, 0x69, 0x78, 0x65,
+	0x6c, 0x41, 0x74, 0x12, 0x22, 0x0a, 0x05, 0x50, 0x68, 0x61, 0x73, 0x65, 0x18, 0x0a, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x43, 0x61, 0x6e, 0x76, 0x61, 0x73, 0x50, 0x68, 0x61, 0x73, 0x65,
+	0x52, 0x05, 0x50, 0x68, 0x61, 0x73, 0x65, 0x22, 0x5b, 0x0a, 0x0d, 0x43, 0x72, 0x65, 0x64, 0x69,
+	0x74, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x28, 0x0a, 0x0f, 0x50, 0x69, 0x78, 0x65,
+	0x6c, 0x73, 0x41, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x0f, 0x50, 0x69, 0x78, 0x65, 0x6c, 0x73, 0x41, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62,
+	0x6c, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x4e, 0x65, 0x78, 0x74, 0x50, 0x69, 0x78, 0x65, 0x6c, 0x41,
+	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x4e, 0x65, 0x78, 0x74, 0x50, 0x69, 0x78,
+	0x65, 0x6c, 0x41, 0x74, 0x22, 0x83, 0x01, 0x0a, 0x0b, 0x43, 0x61, 0x6e, 0x76, 0x61, 0x73, 0x50,
+	0x68, 0x61, 0x73, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x53, 0x74, 0x61, 0x74, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x53, 0x74,
+	0x61, 0x72, 0x74, 0x73, 0x41, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x53, 0x74,
+	0x61, 0x72, 0x74, 0x73, 0x41, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x45, 0x6e, 0x64, 0x73, 0x41, 0x74,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x45, 0x6e, 0x64, 0x73, 0x41, 0x74, 0x12, 0x2a,
+	0x0a, 0x10, 0x43, 0x61, 0x6e, 0x76, 0x61, 0x73, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69,
+	0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x43, 0x61, 0x6e, 0x76, 0x61, 0x73,
+	0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x22, 0x80, 0x01, 0x0a, 0x0c, 0x43,
+	0x61, 0x6e, 0x76, 0x61, 0x73, 0x52, 0x65, 0x73, 0x69, 0x7a, 0x65, 0x12, 0x2a, 0x0a, 0x10, 0x43,
+	0x61, 0x6e, 0x76, 0x61, 0x73, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x43, 0x61, 0x6e, 0x76, 0x61, 0x73, 0x49, 0x64, 0x65,
+	0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x12, 0x20, 0x0a, 0x0b, 0x43, 0x61, 0x6e, 0x76, 0x61,
+	0x73, 0x57, 0x69, 0x64, 0x74, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x43, 0x61,
+	0x6e, 0x76, 0x61, 0x73, 0x57, 0x69, 0x64, 0x74, 0x68, 0x12, 0x22, 0x0a, 0x0c, 0x43, 0x61, 0x6e,
+	0x76, 0x61, 0x73, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x0c, 0x43, 0x61, 0x6e, 0x76, 0x61, 0x73, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x22, 0xc7, 0x04,
+	0x0a, 0x0e, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x45, 0x6e, 0x76, 0x65, 0x6c, 0x6f, 0x70, 0x65,
+	0x12, 0x21, 0x0a, 0x06, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x07, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x48, 0x00, 0x52, 0x06, 0x43, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x12, 0x29, 0x0a, 0x06, 0x43, 0x61, 0x6e, 0x76, 0x61, 0x73, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x43, 0x61, 0x6e, 0x76, 0x61, 0x73, 0x53, 0x6e, 0x61, 0x70,
+	0x73, 0x68, 0x6f, 0x74, 0x48, 0x00, 0x52, 0x06, 0x43, 0x61, 0x6e, 0x76, 0x61, 0x73, 0x12, 0x22,
+	0x0a, 0x05, 0x50, 0x69, 0x78, 0x65, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0a, 0x2e,
+	0x50, 0x69, 0x78, 0x65, 0x6c, 0x49, 0x6e, 0x66, 0x6f, 0x48, 0x00, 0x52, 0x05, 0x50, 0x69, 0x78,
+	0x65, 0x6c, 0x12, 0x27, 0x0a, 0x08, 0x43, 0x6f, 0x6f, 0x6c, 0x64, 0x6f, 0x77, 0x6e, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x09, 0x2e, 0x43, 0x6f, 0x6f, 0x6c, 0x64, 0x6f, 0x77, 0x6e, 0x48,
+	0x00, 0x52, 0x08, 0x43, 0x6f, 0x6f, 0x6c, 0x64, 0x6f, 0x77, 0x6e, 0x12, 0x34, 0x0a, 0x0b, 0x4c,
+	0x65, 0x61, 0x64, 0x65, 0x72, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x10, 0x2e, 0x4c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x50, 0x61,
+	0x67, 0x65, 0x48, 0x00, 0x52, 0x0b, 0x4c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x62, 0x6f, 0x61, 0x72,
+	0x64, 0x12, 0x21, 0x0a, 0x06, 0x50, 0x6c, 0x61, 0x63, 0x65, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x07, 0x2e, 0x50, 0x6c, 0x61, 0x63, 0x65, 0x64, 0x48, 0x00, 0x52, 0x06, 0x50, 0x6c,
+	0x61, 0x63, 0x65, 0x64, 0x12, 0x26, 0x0a, 0x06, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x50, 0x69, 0x78, 0x65, 0x6c, 0x55, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x48, 0x00, 0x52, 0x06, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x2f, 0x0a, 0x0b,
+	0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x42, 0x61, 0x74, 0x63, 0x68, 0x18, 0x08, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x0b, 0x2e, 0x50, 0x69, 0x78, 0x65, 0x6c, 0x42, 0x61, 0x74, 0x63, 0x68, 0x48, 0x00,
+	0x52, 0x0b, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x42, 0x61, 0x74, 0x63, 0x68, 0x12, 0x2a, 0x0a,
+	0x09, 0x52, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x0a, 0x2e, 0x52, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x00, 0x52, 0x09,
+	0x52, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x2a, 0x0a, 0x07, 0x43, 0x72, 0x65,
+	0x64, 0x69, 0x74, 0x73, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x43, 0x72, 0x65,
+	0x64, 0x69, 0x74, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x48, 0x00, 0x52, 0x07, 0x43, 0x72,
+	0x65, 0x64, 0x69, 0x74, 0x73, 0x12, 0x30, 0x0a, 0x0b, 0x43, 0x61, 0x6e, 0x76, 0x61, 0x73, 0x53,
+	0x74, 0x61, 0x74, 0x65, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x43, 0x61, 0x6e,
+	0x76, 0x61, 0x73, 0x50, 0x68, 0x61, 0x73, 0x65, 0x48, 0x00, 0x52, 0x0b, 0x43, 0x61, 0x6e, 0x76,
+	0x61, 0x73, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x35, 0x0a, 0x0d, 0x43, 0x61, 0x6e, 0x76, 0x61,
+	0x73, 0x52, 0x65, 0x73, 0x69, 0x7a, 0x65, 0x64, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d,
+	0x2e, 0x43, 0x61, 0x6e, 0x76, 0x61, 0x73, 0x52, 0x65, 0x73, 0x69, 0x7a, 0x65, 0x48, 0x00, 0x52,
+	0x0d, 0x43, 0x61, 0x6e, 0x76, 0x61, 0x73, 0x52, 0x65, 0x73, 0x69, 0x7a, 0x65, 0x64, 0x12, 0x1c,
+	0x0a, 0x09, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x64, 0x18, 0x0d, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x09, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x64, 0x42, 0x09, 0x0a, 0x07,
+	0x50, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x22, 0x3b, 0x0a, 0x0d, 0x43, 0x61, 0x6e, 0x76, 0x61,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2a, 0x0a, 0x10, 0x43, 0x61, 0x6e, 0x76,
+	0x61, 0x73, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x10, 0x43, 0x61, 0x6e, 0x76, 0x61, 0x73, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69,
+	0x66, 0x69, 0x65, 0x72, 0x22, 0x85, 0x01, 0x0a, 0x0d, 0x52, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2a, 0x0a, 0x10, 0x43, 0x61, 0x6e, 0x76, 0x61, 0x73,
+	0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x10, 0x43, 0x61, 0x6e, 0x76, 0x61, 0x73, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69,
+	0x65, 0x72, 0x12, 0x0c, 0x0a, 0x01, 0x58, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x01, 0x58,
+	0x12, 0x0c, 0x0a, 0x01, 0x59, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x01, 0x59, 0x12, 0x14,
+	0x0a, 0x05, 0x57, 0x69, 0x64, 0x74, 0x68, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x57,
+	0x69, 0x64, 0x74, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x22, 0x70, 0x0a, 0x0c,
+	0x43, 0x61, 0x6e, 0x76, 0x61, 0x73, 0x52, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x12, 0x0c, 0x0a, 0x01,
+	0x58, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x01, 0x58, 0x12, 0x0c, 0x0a, 0x01, 0x59, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x01, 0x59, 0x12, 0x14, 0x0a, 0x05, 0x57, 0x69, 0x64, 0x74,
+	0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x57, 0x69, 0x64, 0x74, 0x68, 0x12, 0x16,
+	0x0a, 0x06, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06,
+	0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x50, 0x69, 0x78, 0x65, 0x6c, 0x73,
+	0x18, 0x05, 0x20, 0x03, 0x28, 0x05, 0x52, 0x06, 0x50, 0x69, 0x78, 0x65, 0x6c, 0x73, 0x22, 0x54,
+	0x0a, 0x0c, 0x50, 0x69, 0x78, 0x65, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2a,
+	0x0a, 0x10, 0x43, 0x61, 0x6e, 0x76, 0x61, 0x73, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69,
+	0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x43, 0x61, 0x6e, 0x76, 0x61, 0x73,
+	0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x12, 0x18, 0x0a, 0x07, 0x50, 0x69,
+	0x78, 0x65, 0x6c, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x50, 0x69, 0x78,
+	0x65, 0x6c, 0x49, 0x64, 0x22, 0xc8, 0x01, 0x0a, 0x0c, 0x50, 0x6c, 0x61, 0x63, 0x65, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2a, 0x0a, 0x10, 0x43, 0x61, 0x6e, 0x76, 0x61, 0x73, 0x49,
+	0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x10, 0x43, 0x61, 0x6e, 0x76, 0x61, 0x73, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65,
+	0x72, 0x12, 0x2e, 0x0a, 0x08, 0x53, 0x65, 0x74, 0x50, 0x69, 0x78, 0x65, 0x6c, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x53, 0x65, 0x74, 0x50, 0x69, 0x78, 0x65, 0x6c, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x08, 0x53, 0x65, 0x74, 0x50, 0x69, 0x78, 0x65,
+	0x6c, 0x12, 0x31, 0x0a, 0x09, 0x53, 0x65, 0x74, 0x50, 0x69, 0x78, 0x65, 0x6c, 0x73, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x53, 0x65, 0x74, 0x50, 0x69, 0x78, 0x65, 0x6c, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x09, 0x53, 0x65, 0x74, 0x50, 0x69,
+	0x78, 0x65, 0x6c, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49,
+	0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x49, 0x64, 0x42, 0x0b, 0x0a, 0x09, 0x50, 0x6c, 0x61, 0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x22,
+	0x5a, 0x0a, 0x0d, 0x50, 0x6c, 0x61, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x1f, 0x0a, 0x06, 0x50, 0x6c, 0x61, 0x63, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x07, 0x2e, 0x50, 0x6c, 0x61, 0x63, 0x65, 0x64, 0x52, 0x06, 0x50, 0x6c, 0x61, 0x63, 0x65,
+	0x64, 0x12, 0x28, 0x0a, 0x07, 0x43, 0x72, 0x65, 0x64, 0x69, 0x74, 0x73, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x43, 0x72, 0x65, 0x64, 0x69, 0x74, 0x42, 0x61, 0x6c, 0x61, 0x6e,
+	0x63, 0x65, 0x52, 0x07, 0x43, 0x72, 0x65, 0x64, 0x69, 0x74, 0x73, 0x22, 0x7b, 0x0a, 0x0d, 0x53,
+	0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2a, 0x0a, 0x10,
+	0x43, 0x61, 0x6e, 0x76, 0x61, 0x73, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x43, 0x61, 0x6e, 0x76, 0x61, 0x73, 0x49, 0x64,
+	0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x12, 0x22, 0x0a, 0x0c, 0x4c, 0x61, 0x73, 0x74,
+	0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c,
+	0x4c, 0x61, 0x73, 0x74, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08,
+	0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08,
+	0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x22, 0x51, 0x0a, 0x0c, 0x43, 0x61, 0x6e, 0x76,
+	0x61, 0x73, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x55, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x55, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x49, 0x64, 0x12, 0x25, 0x0a, 0x05, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x45, 0x6e, 0x76, 0x65,
+	0x6c, 0x6f, 0x70, 0x65, 0x52, 0x05, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x2a, 0xfa, 0x02, 0x0a, 0x06,
+	0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x08, 0x0a, 0x04, 0x4e, 0x4f, 0x4e, 0x45, 0x10, 0x00,
+	0x12, 0x12, 0x0a, 0x0e, 0x49, 0x4e, 0x54, 0x45, 0x52, 0x4e, 0x41, 0x4c, 0x5f, 0x45, 0x52, 0x52,
+	0x4f, 0x52, 0x10, 0x01, 0x12, 0x13, 0x0a, 0x0f, 0x49, 0x4e, 0x56, 0x41, 0x4c, 0x49, 0x44, 0x5f,
+	0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x10, 0x02, 0x12, 0x0d, 0x0a, 0x09, 0x54, 0x48, 0x52,
+	0x4f, 0x54, 0x54, 0x4c, 0x45, 0x44, 0x10, 0x03, 0x12, 0x17, 0x0a, 0x13, 0x53, 0x50, 0x45, 0x43,
+	0x54, 0x41, 0x54, 0x4f, 0x52, 0x5f, 0x52, 0x45, 0x41, 0x44, 0x5f, 0x4f, 0x4e, 0x4c, 0x59, 0x10,
+	0x04, 0x12, 0x11, 0x0a, 0x0d, 0x49, 0x4e, 0x56, 0x41, 0x4c, 0x49, 0x44, 0x5f, 0x42, 0x52, 0x55,
+	0x53, 0x48, 0x10, 0x05, 0x12, 0x11, 0x0a, 0x0d, 0x49, 0x4e, 0x56, 0x41, 0x4c, 0x49, 0x44, 0x5f,
+	0x50, 0x49, 0x58, 0x45, 0x4c, 0x10, 0x06, 0x12, 0x13, 0x0a, 0x0f, 0x43, 0x41, 0x4e, 0x56, 0x41,
+	0x53, 0x5f, 0x4e, 0x4f, 0x54, 0x5f, 0x4f, 0x50, 0x45, 0x4e, 0x10, 0x07, 0x12, 0x13, 0x0a, 0x0f,
+	0x43, 0x41, 0x4e, 0x56, 0x41, 0x53, 0x5f, 0x52, 0x45, 0x53, 0x49, 0x5a, 0x49, 0x4e, 0x47, 0x10,
+	0x08, 0x12, 0x0a, 0x0a, 0x06, 0x42, 0x41, 0x4e, 0x4e, 0x45, 0x44, 0x10, 0x09, 0x12, 0x09, 0x0a,
+	0x05, 0x4d, 0x55, 0x54, 0x45, 0x44, 0x10, 0x0a, 0x12, 0x0f, 0x0a, 0x0b, 0x54, 0x45, 0x41, 0x4d,
+	0x5f, 0x52, 0x45, 0x47, 0x49, 0x4f, 0x4e, 0x10, 0x0b, 0x12, 0x11, 0x0a, 0x0d, 0x55, 0x53, 0x45,
+	0x52, 0x5f, 0x43, 0x4f, 0x4f, 0x4c, 0x44, 0x4f, 0x57, 0x4e, 0x10, 0x0c, 0x12, 0x12, 0x0a, 0x0e,
+	0x50, 0x49, 0x58, 0x45, 0x4c, 0x5f, 0x43, 0x4f, 0x4f, 0x4c, 0x44, 0x4f, 0x57, 0x4e, 0x10, 0x0d,
+	0x12, 0x16, 0x0a, 0x12, 0x4e, 0x4f, 0x54, 0x5f, 0x45, 0x4e, 0x4f, 0x55, 0x47, 0x48, 0x5f, 0x43,
+	0x52, 0x45, 0x44, 0x49, 0x54, 0x53, 0x10, 0x0e, 0x12, 0x13, 0x0a, 0x0f, 0x4e, 0x4f, 0x54, 0x48,
+	0x49, 0x4e, 0x47, 0x5f, 0x54, 0x4f, 0x5f, 0x55, 0x4e, 0x44, 0x4f, 0x10, 0x0f, 0x12, 0x1e, 0x0a,
+	0x1a, 0x49, 0x4e, 0x56, 0x41, 0x4c, 0x49, 0x44, 0x5f, 0x4c, 0x45, 0x41, 0x44, 0x45, 0x52, 0x42,
+	0x4f, 0x41, 0x52, 0x44, 0x5f, 0x57, 0x49, 0x4e, 0x44, 0x4f, 0x57, 0x10, 0x10, 0x12, 0x12, 0x0a,
+	0x0e, 0x49, 0x4e, 0x56, 0x41, 0x4c, 0x49, 0x44, 0x5f, 0x43, 0x41, 0x4e, 0x56, 0x41, 0x53, 0x10,
+	0x11, 0x12, 0x15, 0x0a, 0x11, 0x54, 0x4f, 0x4f, 0x5f, 0x4d, 0x41, 0x4e, 0x59, 0x5f, 0x43, 0x41,
+	0x4e, 0x56, 0x41, 0x53, 0x45, 0x53, 0x10, 0x12, 0x32, 0x96, 0x02, 0x0a, 0x0d, 0x43, 0x61, 0x6e,
+	0x76, 0x61, 0x73, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x24, 0x0a, 0x09, 0x47, 0x65,
+	0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x0e, 0x2e, 0x43, 0x61, 0x6e, 0x76, 0x61, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x07, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67,
+	0x12, 0x2c, 0x0a, 0x09, 0x47, 0x65, 0x74, 0x43, 0x61, 0x6e, 0x76, 0x61, 0x73, 0x12, 0x0e, 0x2e,
+	0x43, 0x61, 0x6e, 0x76, 0x61, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0f, 0x2e,
+	0x43, 0x61, 0x6e, 0x76, 0x61, 0x73, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x12, 0x2a,
+	0x0a, 0x09, 0x47, 0x65, 0x74, 0x52, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x12, 0x0e, 0x2e, 0x52, 0x65,
+	0x67, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0d, 0x2e, 0x43, 0x61,
+	0x6e, 0x76, 0x61, 0x73, 0x52, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x12, 0x25, 0x0a, 0x08, 0x47, 0x65,
+	0x74, 0x50, 0x69, 0x78, 0x65, 0x6c, 0x12, 0x0d, 0x2e, 0x50, 0x69, 0x78, 0x65, 0x6c, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0a, 0x2e, 0x50, 0x69, 0x78, 0x65, 0x6c, 0x49, 0x6e, 0x66,
+	0x6f, 0x12, 0x2c, 0x0a, 0x0b, 0x50, 0x6c, 0x61, 0x63, 0x65, 0x50, 0x69, 0x78, 0x65, 0x6c, 0x73,
+	0x12, 0x0d, 0x2e, 0x50, 0x6c, 0x61, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x0e, 0x2e, 0x50, 0x6c, 0x61, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x30, 0x0a, 0x0d, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x73,
+	0x12, 0x0e, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x0d, 0x2e, 0x43, 0x61, 0x6e, 0x76, 0x61, 0x73, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x30,
+	0x01, 0x42, 0x21, 0x5a, 0x1f, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f,
+	0x72, 0x69, 0x73, 0x68, 0x69, 0x72, 0x61, 0x6a, 0x70, 0x61, 0x6c, 0x30, 0x31, 0x2f, 0x63, 0x61,
+	0x6e, 0x76, 0x61, 0x73, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_definitions_proto_rawDescOnce sync.Once
+	file_definitions_proto_rawDescData = file_definitions_proto_rawDesc
+)
+
+func file_definitions_proto_rawDescGZIP() []byte {
+	file_definitions_proto_rawDescOnce.Do(func() {
+		file_definitions_proto_rawDescData = protoimpl.X.CompressGZIP(file_definitions_proto_rawDescData)
+	})
+	return file_definitions_proto_rawDescData
+}
+
+var file_definitions_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_definitions_proto_msgTypes = make([]protoimpl.MessageInfo, 36)
+var file_definitions_proto_goTypes = []interface{}{
+	(Reason)(0),                   // 0: Reason
+	(*RequestMessage)(nil),        // 1: RequestMessage
+	(*Pixel)(nil),                 // 2: Pixel
+	(*LeaderboardEntry)(nil),      // 3: LeaderboardEntry
+	(*PaletteColor)(nil),          // 4: PaletteColor
+	(*ResponseMessage)(nil),       // 5: ResponseMessage
+	(*GetConfigRequest)(nil),      // 6: GetConfigRequest
+	(*GetCanvasRequest)(nil),      // 7: GetCanvasRequest
+	(*SetPixelRequest)(nil),       // 8: SetPixelRequest
+	(*ViewPixelRequest)(nil),      // 9: ViewPixelRequest
+	(*GetCooldownRequest)(nil),    // 10: GetCooldownRequest
+	(*GetLeaderboardRequest)(nil), // 11: GetLeaderboardRequest
+	(*SetPixelsRequest)(nil),      // 12: SetPixelsRequest
+	(*UndoPlacementRequest)(nil),  // 13: UndoPlacementRequest
+	(*JoinCanvasRequest)(nil),     // 14: JoinCanvasRequest
+	(*ClientEnvelope)(nil),        // 15: ClientEnvelope
+	(*Config)(nil),                // 16: Config
+	(*CanvasSnapshot)(nil),        // 17: CanvasSnapshot
+	(*PixelInfo)(nil),             // 18: PixelInfo
+	(*Cooldown)(nil),              // 19: Cooldown
+	(*LeaderboardPage)(nil),       // 20: LeaderboardPage
+	(*Placed)(nil),                // 21: Placed
+	(*PixelUpdate)(nil),           // 22: PixelUpdate
+	(*PixelBatch)(nil),            // 23: PixelBatch
+	(*Rejection)(nil),             // 24: Rejection
+	(*CreditBalance)(nil),         // 25: CreditBalance
+	(*CanvasPhase)(nil),           // 26: CanvasPhase
+	(*CanvasResize)(nil),          // 27: CanvasResize
+	(*ServerEnvelope)(nil),        // 28: ServerEnvelope
+	(*CanvasRequest)(nil),         // 29: CanvasRequest
+	(*RegionRequest)(nil),         // 30: RegionRequest
+	(*CanvasRegion)(nil),          // 31: CanvasRegion
+	(*PixelRequest)(nil),          // 32: PixelRequest
+	(*PlaceRequest)(nil),          // 33: PlaceRequest
+	(*PlaceResponse)(nil),         // 34: PlaceResponse
+	(*StreamRequest)(nil),         // 35: StreamRequest
+	(*CanvasUpdate)(nil),          // 36: CanvasUpdate
+}
+var file_definitions_proto_depIdxs = []int32{
+	3,  // 0: ResponseMessage.Leaderboard:type_name -> LeaderboardEntry
+	4,  // 1: ResponseMessage.Palette:type_name -> PaletteColor
+	2,  // 2: ResponseMessage.Pixels:type_name -> Pixel
+	0,  // 3: ResponseMessage.Reason:type_name -> Reason
+	6,  // 4: ClientEnvelope.GetConfig:type_name -> GetConfigRequest
+	7,  // 5: ClientEnvelope.GetCanvas:type_name -> GetCanvasRequest
+	8,  // 6: ClientEnvelope.SetPixel:type_name -> SetPixelRequest
+	9,  // 7: ClientEnvelope.ViewPixel:type_name -> ViewPixelRequest
+	10, // 8: ClientEnvelope.GetCooldown:type_name -> GetCooldownRequest
+	11, // 9: ClientEnvelope.GetLeaderboard:type_name -> GetLeaderboardRequest
+	12, // 10: ClientEnvelope.SetPixels:type_name -> SetPixelsRequest
+	13, // 11: ClientEnvelope.UndoPlacement:type_name -> UndoPlacementRequest
+	14, // 12: ClientEnvelope.JoinCanvas:type_name -> JoinCanvasRequest
+	26, // 13: Config.Phase:type_name -> CanvasPhase
+	4,  // 14: Config.Palette:type_name -> PaletteColor
+	3,  // 15: LeaderboardPage.Entries:type_name -> LeaderboardEntry
+	2,  // 16: Placed.Pixels:type_name -> Pixel
+	2,  // 17: PixelBatch.Pixels:type_name -> Pixel
+	0,  // 18: Rejection.Reason:type_name -> Reason
+	26, // 19: Rejection.Phase:type_name -> CanvasPhase
+	16, // 20: ServerEnvelope.Config:type_name -> Config
+	17, // 21: ServerEnvelope.Canvas:type_name -> CanvasSnapshot
+	18, // 22: ServerEnvelope.Pixel:type_name -> PixelInfo
+	19, // 23: ServerEnvelope.Cooldown:type_name -> Cooldown
+	20, // 24: ServerEnvelope.Leaderboard:type_name -> LeaderboardPage
+	21, // 25: ServerEnvelope.Placed:type_name -> Placed
+	22, // 26: ServerEnvelope.Update:type_name -> PixelUpdate
+	23, // 27: ServerEnvelope.UpdateBatch:type_name -> PixelBatch
+	24, // 28: ServerEnvelope.Rejection:type_name -> Rejection
+	25, // 29: ServerEnvelope.Credits:type_name -> CreditBalance
+	26, // 30: ServerEnvelope.CanvasState:type_name -> CanvasPhase
+	27, // 31: ServerEnvelope.CanvasResized:type_name -> CanvasResize
+	8,  // 32: PlaceRequest.SetPixel:type_name -> SetPixelRequest
+	12, // 33: PlaceRequest.SetPixels:type_name -> SetPixelsRequest
+	21, // 34: PlaceResponse.Placed:type_name -> Placed
+	25, // 35: PlaceResponse.Credits:type_name -> CreditBalance
+	28, // 36: CanvasUpdate.Event:type_name -> ServerEnvelope
+	29, // 37: CanvasService.GetConfig:input_type -> CanvasRequest
+	29, // 38: CanvasService.GetCanvas:input_type -> CanvasRequest
+	30, // 39: CanvasService.GetRegion:input_type -> RegionRequest
+	32, // 40: CanvasService.GetPixel:input_type -> PixelRequest
+	33, // 41: CanvasService.PlacePixels:input_type -> PlaceRequest
+	35, // 42: CanvasService.StreamUpdates:input_type -> StreamRequest
+	16, // 43: CanvasService.GetConfig:output_type -> Config
+	17, // 44: CanvasService.GetCanvas:output_type -> CanvasSnapshot
+	31, // 45: CanvasService.GetRegion:output_type -> CanvasRegion
+	18, // 46: CanvasService.GetPixel:output_type -> PixelInfo
+	34, // 47: CanvasService.PlacePixels:output_type -> PlaceResponse
+	36, // 48: CanvasService.StreamUpdates:output_type -> CanvasUpdate
+	43, // [43:49] is the sub-list for method output_type
+	37, // [37:43] is the sub-list for method input_type
+	37, // [37:37] is the sub-list for extension type_name
+	37, // [37:37] is the sub-list for extension extendee
+	0,  // [0:37] is the sub-list for field type_name
+}
+
+func init() { file_definitions_proto_init() }
+func file_definitions_proto_init() {
+	if File_definitions_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_definitions_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RequestMessage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_definitions_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Pixel); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_definitions_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LeaderboardEntry); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_definitions_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PaletteColor); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_definitions_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResponseMessage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_definitions_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetConfigRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_definitions_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetCanvasRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_definitions_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetPixelRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_definitions_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ViewPixelRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_definitions_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetCooldownRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -312,8 +3733,308 @@ func file_definitions_proto_init() {
 				return nil
 			}
 		}
-		file_definitions_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ResponseMessage); i {
+		file_definitions_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetLeaderboardRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_definitions_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetPixelsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_definitions_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UndoPlacementRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_definitions_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*JoinCanvasRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_definitions_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ClientEnvelope); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_definitions_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Config); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_definitions_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CanvasSnapshot); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_definitions_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PixelInfo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_definitions_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Cooldown); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_definitions_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LeaderboardPage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_definitions_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Placed); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_definitions_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PixelUpdate); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_definitions_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PixelBatch); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_definitions_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Rejection); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_definitions_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreditBalance); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_definitions_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CanvasPhase); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_definitions_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CanvasResize); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_definitions_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ServerEnvelope); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_definitions_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CanvasRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_definitions_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RegionRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_definitions_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CanvasRegion); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_definitions_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PixelRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_definitions_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PlaceRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_definitions_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PlaceResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_definitions_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StreamRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_definitions_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CanvasUpdate); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -325,18 +4046,48 @@ func file_definitions_proto_init() {
 			}
 		}
 	}
+	file_definitions_proto_msgTypes[14].OneofWrappers = []interface{}{
+		(*ClientEnvelope_GetConfig)(nil),
+		(*ClientEnvelope_GetCanvas)(nil),
+		(*ClientEnvelope_SetPixel)(nil),
+		(*ClientEnvelope_ViewPixel)(nil),
+		(*ClientEnvelope_GetCooldown)(nil),
+		(*ClientEnvelope_GetLeaderboard)(nil),
+		(*ClientEnvelope_SetPixels)(nil),
+		(*ClientEnvelope_UndoPlacement)(nil),
+		(*ClientEnvelope_JoinCanvas)(nil),
+	}
+	file_definitions_proto_msgTypes[27].OneofWrappers = []interface{}{
+		(*ServerEnvelope_Config)(nil),
+		(*ServerEnvelope_Canvas)(nil),
+		(*ServerEnvelope_Pixel)(nil),
+		(*ServerEnvelope_Cooldown)(nil),
+		(*ServerEnvelope_Leaderboard)(nil),
+		(*ServerEnvelope_Placed)(nil),
+		(*ServerEnvelope_Update)(nil),
+		(*ServerEnvelope_UpdateBatch)(nil),
+		(*ServerEnvelope_Rejection)(nil),
+		(*ServerEnvelope_Credits)(nil),
+		(*ServerEnvelope_CanvasState)(nil),
+		(*ServerEnvelope_CanvasResized)(nil),
+	}
+	file_definitions_proto_msgTypes[32].OneofWrappers = []interface{}{
+		(*PlaceRequest_SetPixel)(nil),
+		(*PlaceRequest_SetPixels)(nil),
+	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_definitions_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   2,
+			NumEnums:      1,
+			NumMessages:   36,
 			NumExtensions: 0,
-			NumServices:   0,
+			NumServices:   1,
 		},
 		GoTypes:           file_definitions_proto_goTypes,
 		DependencyIndexes: file_definitions_proto_depIdxs,
+		EnumInfos:         file_definitions_proto_enumTypes,
 		MessageInfos:      file_definitions_proto_msgTypes,
 	}.Build()
 	File_definitions_proto = out.File
@@ -0,0 +1,322 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v3.6.1
+// source: definitions.proto
+
+package canvas
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	CanvasService_GetConfig_FullMethodName     = "/CanvasService/GetConfig"
+	CanvasService_GetCanvas_FullMethodName     = "/CanvasService/GetCanvas"
+	CanvasService_GetRegion_FullMethodName     = "/CanvasService/GetRegion"
+	CanvasService_GetPixel_FullMethodName      = "/CanvasService/GetPixel"
+	CanvasService_PlacePixels_FullMethodName   = "/CanvasService/PlacePixels"
+	CanvasService_StreamUpdates_FullMethodName = "/CanvasService/StreamUpdates"
+)
+
+// CanvasServiceClient is the client API for CanvasService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type CanvasServiceClient interface {
+	GetConfig(ctx context.Context, in *CanvasRequest, opts ...grpc.CallOption) (*Config, error)
+	GetCanvas(ctx context.Context, in *CanvasRequest, opts ...grpc.CallOption) (*CanvasSnapshot, error)
+	GetRegion(ctx context.Context, in *RegionRequest, opts ...grpc.CallOption) (*CanvasRegion, error)
+	GetPixel(ctx context.Context, in *PixelRequest, opts ...grpc.CallOption) (*PixelInfo, error)
+	PlacePixels(ctx context.Context, in *PlaceRequest, opts ...grpc.CallOption) (*PlaceResponse, error)
+	StreamUpdates(ctx context.Context, in *StreamRequest, opts ...grpc.CallOption) (CanvasService_StreamUpdatesClient, error)
+}
+
+type canvasServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewCanvasServiceClient(cc grpc.ClientConnInterface) CanvasServiceClient {
+	return &canvasServiceClient{cc}
+}
+
+func (c *canvasServiceClient) GetConfig(ctx context.Context, in *CanvasRequest, opts ...grpc.CallOption) (*Config, error) {
+	out := new(Config)
+	err := c.cc.Invoke(ctx, CanvasService_GetConfig_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *canvasServiceClient) GetCanvas(ctx context.Context, in *CanvasRequest, opts ...grpc.CallOption) (*CanvasSnapshot, error) {
+	out := new(CanvasSnapshot)
+	err := c.cc.Invoke(ctx, CanvasService_GetCanvas_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *canvasServiceClient) GetRegion(ctx context.Context, in *RegionRequest, opts ...grpc.CallOption) (*CanvasRegion, error) {
+	out := new(CanvasRegion)
+	err := c.cc.Invoke(ctx, CanvasService_GetRegion_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *canvasServiceClient) GetPixel(ctx context.Context, in *PixelRequest, opts ...grpc.CallOption) (*PixelInfo, error) {
+	out := new(PixelInfo)
+	err := c.cc.Invoke(ctx, CanvasService_GetPixel_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *canvasServiceClient) PlacePixels(ctx context.Context, in *PlaceRequest, opts ...grpc.CallOption) (*PlaceResponse, error) {
+	out := new(PlaceResponse)
+	err := c.cc.Invoke(ctx, CanvasService_PlacePixels_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *canvasServiceClient) StreamUpdates(ctx context.Context, in *StreamRequest, opts ...grpc.CallOption) (CanvasService_StreamUpdatesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &CanvasService_ServiceDesc.Streams[0], CanvasService_StreamUpdates_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &canvasServiceStreamUpdatesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type CanvasService_StreamUpdatesClient interface {
+	Recv() (*CanvasUpdate, error)
+	grpc.ClientStream
+}
+
+type canvasServiceStreamUpdatesClient struct {
+	grpc.ClientStream
+}
+
+func (x *canvasServiceStreamUpdatesClient) Recv() (*CanvasUpdate, error) {
+	m := new(CanvasUpdate)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// CanvasServiceServer is the server API for CanvasService service.
+// All implementations must embed UnimplementedCanvasServiceServer
+// for forward compatibility
+type CanvasServiceServer interface {
+	GetConfig(context.Context, *CanvasRequest) (*Config, error)
+	GetCanvas(context.Context, *CanvasRequest) (*CanvasSnapshot, error)
+	GetRegion(context.Context, *RegionRequest) (*CanvasRegion, error)
+	GetPixel(context.Context, *PixelRequest) (*PixelInfo, error)
+	PlacePixels(context.Context, *PlaceRequest) (*PlaceResponse, error)
+	StreamUpdates(*StreamRequest, CanvasService_StreamUpdatesServer) error
+	mustEmbedUnimplementedCanvasServiceServer()
+}
+
+// UnimplementedCanvasServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedCanvasServiceServer struct {
+}
+
+func (UnimplementedCanvasServiceServer) GetConfig(context.Context, *CanvasRequest) (*Config, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetConfig not implemented")
+}
+func (UnimplementedCanvasServiceServer) GetCanvas(context.Context, *CanvasRequest) (*CanvasSnapshot, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCanvas not implemented")
+}
+func (UnimplementedCanvasServiceServer) GetRegion(context.Context, *RegionRequest) (*CanvasRegion, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRegion not implemented")
+}
+func (UnimplementedCanvasServiceServer) GetPixel(context.Context, *PixelRequest) (*PixelInfo, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPixel not implemented")
+}
+func (UnimplementedCanvasServiceServer) PlacePixels(context.Context, *PlaceRequest) (*PlaceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PlacePixels not implemented")
+}
+func (UnimplementedCanvasServiceServer) StreamUpdates(*StreamRequest, CanvasService_StreamUpdatesServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamUpdates not implemented")
+}
+func (UnimplementedCanvasServiceServer) mustEmbedUnimplementedCanvasServiceServer() {}
+
+// UnsafeCanvasServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to CanvasServiceServer will
+// result in compilation errors.
+type UnsafeCanvasServiceServer interface {
+	mustEmbedUnimplementedCanvasServiceServer()
+}
+
+func RegisterCanvasServiceServer(s grpc.ServiceRegistrar, srv CanvasServiceServer) {
+	s.RegisterService(&CanvasService_ServiceDesc, srv)
+}
+
+func _CanvasService_GetConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CanvasRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CanvasServiceServer).GetConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CanvasService_GetConfig_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CanvasServiceServer).GetConfig(ctx, req.(*CanvasRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CanvasService_GetCanvas_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CanvasRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CanvasServiceServer).GetCanvas(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CanvasService_GetCanvas_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CanvasServiceServer).GetCanvas(ctx, req.(*CanvasRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CanvasService_GetRegion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CanvasServiceServer).GetRegion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CanvasService_GetRegion_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CanvasServiceServer).GetRegion(ctx, req.(*RegionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CanvasService_GetPixel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PixelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CanvasServiceServer).GetPixel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CanvasService_GetPixel_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CanvasServiceServer).GetPixel(ctx, req.(*PixelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CanvasService_PlacePixels_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PlaceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CanvasServiceServer).PlacePixels(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CanvasService_PlacePixels_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CanvasServiceServer).PlacePixels(ctx, req.(*PlaceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CanvasService_StreamUpdates_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CanvasServiceServer).StreamUpdates(m, &canvasServiceStreamUpdatesServer{stream})
+}
+
+type CanvasService_StreamUpdatesServer interface {
+	Send(*CanvasUpdate) error
+	grpc.ServerStream
+}
+
+type canvasServiceStreamUpdatesServer struct {
+	grpc.ServerStream
+}
+
+func (x *canvasServiceStreamUpdatesServer) Send(m *CanvasUpdate) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// CanvasService_ServiceDesc is the grpc.ServiceDesc for CanvasService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var CanvasService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "CanvasService",
+	HandlerType: (*CanvasServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetConfig",
+			Handler:    _CanvasService_GetConfig_Handler,
+		},
+		{
+			MethodName: "GetCanvas",
+			Handler:    _CanvasService_GetCanvas_Handler,
+		},
+		{
+			MethodName: "GetRegion",
+			Handler:    _CanvasService_GetRegion_Handler,
+		},
+		{
+			MethodName: "GetPixel",
+			Handler:    _CanvasService_GetPixel_Handler,
+		},
+		{
+			MethodName: "PlacePixels",
+			Handler:    _CanvasService_PlacePixels_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamUpdates",
+			Handler:       _CanvasService_StreamUpdates_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "definitions.proto",
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"bytes"
+	"canvas/catalogue"
+	"canvas/connections"
+	"canvas/functions"
+	"canvas/models"
+	"log"
+	"net/http"
+	"strconv"
+)
+
+// #region Stats
+
+// getStats reports the players and spectators connected to this instance per canvas
+func getStats(w http.ResponseWriter, r *http.Request) {
+	stats := map[string]*models.ConnectionStats{}
+	clients.Range(func(key, value interface{}) bool {
+		client := key.(*models.Client)
+		canvasStats, ok := stats[client.Canvas()]
+		if !ok {
+			canvasStats = &models.ConnectionStats{}
+			stats[client.Canvas()] = canvasStats
+		}
+		if client.Spectator {
+			canvasStats.Spectators++
+		} else {
+			canvasStats.Players++
+		}
+		return true
+	})
+	writeJSON(w, http.StatusOK, stats)
+}
+
+// getTeamStats reports the placements of every team on a canvas
+func getTeamStats(w http.ResponseWriter, r *http.Request) {
+	canvasIdentifier := r.PathValue("canvasIdentifier")
+	if !functions.CanvasExists(catalogue.CANVAS_LIST, canvasIdentifier) {
+		writeError(w, http.StatusNotFound, "Invalid Canvas Identifier")
+		return
+	}
+	stats, err := functions.GetTeamStats(canvasIdentifier, connections.RedisClient)
+	if err != nil {
+		log.Println("ERR56: ", err)
+		writeError(w, http.StatusInternalServerError, "Error getting team stats!")
+		return
+	}
+	writeJSON(w, http.StatusOK, stats)
+}
+
+// getLeaderboard pages through a leaderboard window, e.g. ?window=daily&offset=10&limit=10
+func getLeaderboard(w http.ResponseWriter, r *http.Request) {
+	canvasIdentifier := r.PathValue("canvasIdentifier")
+	if !functions.CanvasExists(catalogue.CANVAS_LIST, canvasIdentifier) {
+		writeError(w, http.StatusNotFound, "Invalid Canvas Identifier")
+		return
+	}
+	query := r.URL.Query()
+	window := query.Get("window")
+	if window == "" {
+		window = models.LEADERBOARD_ALL
+	}
+	if !functions.IsLeaderboardWindow(window) {
+		writeError(w, http.StatusBadRequest, "Invalid window")
+		return
+	}
+	offset, err := strconv.ParseInt(query.Get("offset"), 10, 64)
+	if (err != nil && query.Get("offset") != "") || offset < 0 {
+		writeError(w, http.StatusBadRequest, "Invalid offset")
+		return
+	}
+	limit, err := strconv.ParseInt(query.Get("limit"), 10, 64)
+	if (err != nil && query.Get("limit") != "") || limit < 0 {
+		writeError(w, http.StatusBadRequest, "Invalid limit")
+		return
+	}
+	leaderboard, err := functions.GetLeaderboard(canvasIdentifier, window, offset, limit, connections.RedisClient)
+	if err != nil {
+		log.Println("ERR87: ", err)
+		writeError(w, http.StatusInternalServerError, "Error getting leaderboard!")
+		return
+	}
+	writeJSON(w, http.StatusOK, leaderboard)
+}
+
+// getPalette lists the colours of a canvas in order, colour n is at index n-1
+func getPalette(w http.ResponseWriter, r *http.Request) {
+	canvasIdentifier := r.PathValue("canvasIdentifier")
+	if !functions.CanvasExists(catalogue.CANVAS_LIST, canvasIdentifier) {
+		writeError(w, http.StatusNotFound, "Invalid Canvas Identifier")
+		return
+	}
+	writeJSON(w, http.StatusOK, catalogue.CanvasPalette(canvasIdentifier))
+}
+
+// getCanvasPNG exports the canvas as a PNG drawn with its palette
+func getCanvasPNG(w http.ResponseWriter, r *http.Request) {
+	canvasIdentifier := r.PathValue("canvasIdentifier")
+	if !functions.CanvasExists(catalogue.CANVAS_LIST, canvasIdentifier) {
+		writeError(w, http.StatusNotFound, "Invalid Canvas Identifier")
+		return
+	}
+	var image bytes.Buffer
+	if err := functions.WriteCanvasPNG(canvasIdentifier, &image, connections.RedisClient); err != nil {
+		log.Println("ERR60: ", err)
+		writeError(w, http.StatusInternalServerError, "Error exporting canvas!")
+		return
+	}
+	w.Header().Set("Content-Type", "image/png")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Write(image.Bytes())
+}
+
+// #endregion Stats